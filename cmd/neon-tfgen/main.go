@@ -0,0 +1,55 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/provider"
+)
+
+// neon-tfgen writes a configuration file per project of an existing Neon
+// account, with the import blocks adopting its resources. Run "terraform plan"
+// on the output directory to review the generated configuration.
+func main() {
+	var out, orgID string
+
+	flag.StringVar(&out, "out", ".", "directory the configuration files are written to")
+	flag.StringVar(&orgID, "org-id", "", "only generate the projects of this organization")
+	flag.Parse()
+
+	key, ok := os.LookupEnv("NEON_API_KEY")
+	if !ok {
+		log.Fatal("NEON_API_KEY must be set")
+	}
+
+	files, err := provider.GenerateConfig(provider.NewClient(key), orgID)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	err = os.MkdirAll(out, 0o755)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, err := os.Stat(filepath.Join(out, name)); err == nil {
+			log.Fatalf("%s already exists", filepath.Join(out, name))
+		}
+	}
+	for _, name := range names {
+		path := filepath.Join(out, name)
+		err = os.WriteFile(path, files[name], 0o644)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+		log.Printf("wrote %s", path)
+	}
+}
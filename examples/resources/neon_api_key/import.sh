@@ -0,0 +1,2 @@
+# Import an api key with its numeric id, the key itself cannot be read back
+terraform import neon_api_key.example 123456
@@ -0,0 +1,6 @@
+# Import a branch with project_id/id
+terraform import neon_branch.example patient-lab-123456/br-cool-wave-123456
+
+# The previous id/project_id format is still accepted, it is recognized by the
+# br- prefix of branch ids
+terraform import neon_branch.example br-cool-wave-123456/patient-lab-123456
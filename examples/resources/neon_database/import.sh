@@ -0,0 +1,2 @@
+# Import a database with project_id/branch_id/name
+terraform import neon_database.example patient-lab-123456/br-cool-wave-123456/app
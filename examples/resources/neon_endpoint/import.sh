@@ -0,0 +1,2 @@
+# Import an endpoint with project_id/id
+terraform import neon_endpoint.example patient-lab-123456/ep-quiet-sun-123456
@@ -0,0 +1,2 @@
+# Import an extension with project_id/branch_id/database_name/name
+terraform import neon_extension.example patient-lab-123456/br-cool-wave-123456/app/pg_trgm
@@ -0,0 +1,10 @@
+# Import a grant on a database with project_id/branch_id/database_name/role/database
+terraform import neon_grant.example patient-lab-123456/br-cool-wave-123456/app/reader/database
+
+# Import a grant on a schema, or on all its tables or sequences, with
+# project_id/branch_id/database_name/role/object_type/schema
+terraform import neon_grant.example patient-lab-123456/br-cool-wave-123456/app/reader/table/public
+
+# Import a grant on some tables or sequences of a schema with
+# project_id/branch_id/database_name/role/object_type/schema/object,object
+terraform import neon_grant.example patient-lab-123456/br-cool-wave-123456/app/reader/table/public/orders,customers
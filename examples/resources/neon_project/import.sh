@@ -0,0 +1,2 @@
+# Import a project with its id
+terraform import neon_project.example patient-lab-123456
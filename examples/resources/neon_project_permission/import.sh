@@ -0,0 +1,2 @@
+# Import a project permission with project_id/email
+terraform import neon_project_permission.example patient-lab-123456/jane@example.com
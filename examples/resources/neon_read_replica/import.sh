@@ -0,0 +1,2 @@
+# Import a read replica with project_id/id
+terraform import neon_read_replica.example patient-lab-123456/ep-quiet-sun-123456
@@ -0,0 +1,2 @@
+# Import a replication slot with project_id/branch_id/database_name/name
+terraform import neon_replication_slot.example patient-lab-123456/br-cool-wave-123456/app/orders_slot
@@ -0,0 +1,2 @@
+# Import a role with project_id/branch_id/name
+terraform import neon_role.example patient-lab-123456/br-cool-wave-123456/reader
@@ -0,0 +1,2 @@
+# Import a vpc endpoint with org_id/region_id/vpc_endpoint_id
+terraform import neon_vpc_endpoint.example org-morning-bread-123456/aws-us-east-1/vpce-0123456789abcdef0
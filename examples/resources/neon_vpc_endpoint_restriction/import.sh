@@ -0,0 +1,2 @@
+# Import a vpc endpoint restriction with project_id/vpc_endpoint_id
+terraform import neon_vpc_endpoint_restriction.example patient-lab-123456/vpce-0123456789abcdef0
@@ -0,0 +1,226 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type apiKeyResource struct {
+	client *resty.Client
+}
+type apiKeyJSON struct {
+	ID               int64  `json:"id"`
+	Key              string `json:"key,omitempty"`
+	Name             string `json:"name"`
+	CreatedAt        string `json:"created_at"`
+	LastUsedAt       string `json:"last_used_at"`
+	LastUsedFromAddr string `json:"last_used_from_addr"`
+}
+type apiKeyResourceModel struct {
+	ID               types.Int64  `tfsdk:"id"`
+	Name             types.String `tfsdk:"name"`
+	Key              types.String `tfsdk:"key"`
+	CreatedAt        types.String `tfsdk:"created_at"`
+	LastUsedAt       types.String `tfsdk:"last_used_at"`
+	LastUsedFromAddr types.String `tfsdk:"last_used_from_addr"`
+}
+
+var _ resource.Resource = apiKeyResource{}
+var _ resource.ResourceWithUpgradeState = apiKeyResource{}
+var _ resource.ResourceWithImportState = apiKeyResource{}
+var _ resource.ResourceWithIdentity = apiKeyResource{}
+
+func (in *apiKeyJSON) toApiKeyResourceModel(key types.String) *apiKeyResourceModel {
+	return &apiKeyResourceModel{
+		ID:               types.Int64Value(in.ID),
+		Name:             types.StringValue(in.Name),
+		Key:              key,
+		CreatedAt:        types.StringValue(in.CreatedAt),
+		LastUsedAt:       types.StringValue(in.LastUsedAt),
+		LastUsedFromAddr: types.StringValue(in.LastUsedFromAddr),
+	}
+}
+
+func listApiKeys(client *resty.Client) ([]apiKeyJSON, error) {
+	response, err := client.R().Get("/api_keys")
+	if err != nil {
+		return nil, err
+	}
+	if response.IsError() {
+		return nil, fmt.Errorf("failed to list api keys with a status code: %s", response.Status())
+	}
+	keys := []apiKeyJSON{}
+	err = json.Unmarshal(response.Body(), &keys)
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (r apiKeyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_key"
+}
+
+func (r apiKeyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version:             1,
+		MarkdownDescription: "Neon api key resource, the key is only known after it is created",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				MarkdownDescription: "api key id",
+				Computed:            true,
+				PlanModifiers:       []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "api key name",
+				Required:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"key": schema.StringAttribute{
+				MarkdownDescription: "api key token",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "created at",
+				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"last_used_at": schema.StringAttribute{
+				MarkdownDescription: "last used at",
+				Computed:            true,
+			},
+			"last_used_from_addr": schema.StringAttribute{
+				MarkdownDescription: "last used from address",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r apiKeyResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: upgradeFromV0(r),
+	}
+}
+
+func (r apiKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data apiKeyResourceModel
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	content := struct {
+		KeyName string `json:"key_name"`
+	}{
+		KeyName: data.Name.ValueString(),
+	}
+	response, _ := r.client.R().
+		SetBody(content).
+		Post("/api_keys")
+	if response.IsError() {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to create api key resource with a status code: %s", response.Status()), "")
+		return
+	}
+	inner := apiKeyJSON{}
+	err := json.Unmarshal(response.Body(), &inner)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to unmarshal response", err.Error())
+		return
+	}
+	inner.Name = data.Name.ValueString()
+	apiKey := inner.toApiKeyResourceModel(types.StringValue(inner.Key))
+	diags = resp.State.Set(ctx, apiKey)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.State, resp.Identity)...)
+}
+
+func (r apiKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data apiKeyResourceModel
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	keys, err := listApiKeys(r.client)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read api key resource", err.Error())
+		return
+	}
+	for _, v := range keys {
+		if v.ID == data.ID.ValueInt64() {
+			diags = resp.State.Set(ctx, v.toApiKeyResourceModel(data.Key))
+			resp.Diagnostics.Append(diags...)
+			resp.Diagnostics.Append(setIdentity(ctx, resp.State, resp.Identity)...)
+			return
+		}
+	}
+	// The key was revoked outside of terraform.
+	resp.State.RemoveResource(ctx)
+}
+
+func (r apiKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data apiKeyResourceModel
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.State, resp.Identity)...)
+}
+
+func (r apiKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data apiKeyResourceModel
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	response, _ := r.client.R().Delete(fmt.Sprintf("/api_keys/%d", data.ID.ValueInt64()))
+	if response.IsError() {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to revoke api key resource with a status code: %s", response.Status()), "")
+		return
+	}
+	resp.State.RemoveResource(ctx)
+}
+
+func (r apiKeyResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.Int64Attribute{
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+// ImportState imports an api key by its id. The key itself is only returned
+// on creation and stays null.
+func (r apiKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID == "" {
+		resp.Diagnostics.Append(importIdentity(ctx, req.Identity, &resp.State)...)
+		return
+	}
+	id, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Cannot import api key", fmt.Sprintf("invalid id '%s' specified, should be the numeric api key id", req.ID))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
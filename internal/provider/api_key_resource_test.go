@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestApiKeyResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testApiKeyResourceCreate("name_api_key"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_api_key.test", "name", "name_api_key"),
+					resource.TestCheckResourceAttrSet("neon_api_key.test", "key"),
+					resource.TestCheckResourceAttrSet("neon_api_key.test", "created_at"),
+				),
+			},
+			// ImportState testing, the key is only known after creation
+			{
+				ResourceName:            "neon_api_key.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"key"},
+			},
+			// Replace testing
+			{
+				Config: testApiKeyResourceCreate("name_api_key_updated"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_api_key.test", "name", "name_api_key_updated"),
+					resource.TestCheckResourceAttrSet("neon_api_key.test", "key"),
+				),
+			},
+		},
+	})
+}
+
+func testApiKeyResourceCreate(name string) string {
+	return `
+resource "neon_api_key" "test" {
+	name = "` + name + `"
+}
+`
+}
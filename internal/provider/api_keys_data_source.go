@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &apiKeysDataSource{}
+
+type apiKeysDataSource struct {
+	client *resty.Client
+}
+
+type apiKeysDataModel struct {
+	ID      types.String `tfsdk:"id"`
+	ApiKeys types.List   `tfsdk:"api_keys"`
+}
+
+type apiKeyDataModel struct {
+	ID               types.Int64  `tfsdk:"id"`
+	Name             types.String `tfsdk:"name"`
+	CreatedAt        types.String `tfsdk:"created_at"`
+	LastUsedAt       types.String `tfsdk:"last_used_at"`
+	LastUsedFromAddr types.String `tfsdk:"last_used_from_addr"`
+}
+
+func (in *apiKeyJSON) ToApiKeyDataModel() *apiKeyDataModel {
+	return &apiKeyDataModel{
+		ID:               types.Int64Value(in.ID),
+		Name:             types.StringValue(in.Name),
+		CreatedAt:        types.StringValue(in.CreatedAt),
+		LastUsedAt:       types.StringValue(in.LastUsedAt),
+		LastUsedFromAddr: types.StringValue(in.LastUsedFromAddr),
+	}
+}
+
+func apiKeysDataApiKeyAttr() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.Int64Attribute{
+			MarkdownDescription: "api key id",
+			Computed:            true,
+		},
+		"name": schema.StringAttribute{
+			MarkdownDescription: "api key name",
+			Computed:            true,
+		},
+		"created_at": schema.StringAttribute{
+			MarkdownDescription: "created at",
+			Computed:            true,
+		},
+		"last_used_at": schema.StringAttribute{
+			MarkdownDescription: "last used at",
+			Computed:            true,
+		},
+		"last_used_from_addr": schema.StringAttribute{
+			MarkdownDescription: "last used from address",
+			Computed:            true,
+		},
+	}
+}
+
+// Metadata implements datasource.DataSource
+func (*apiKeysDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_keys"
+}
+
+// Read implements datasource.DataSource
+func (d *apiKeysDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data apiKeysDataModel
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keys, err := listApiKeys(d.client)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to list api keys", err.Error())
+		return
+	}
+	k := []apiKeyDataModel{}
+	for _, v := range keys {
+		k = append(k, *v.ToApiKeyDataModel())
+	}
+	aux, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: typeFromDataAttrs(apiKeysDataApiKeyAttr())}, k)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ApiKeys = aux
+	data.ID = types.StringValue("api_keys")
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Schema implements datasource.DataSource
+func (*apiKeysDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "placeholder identifier",
+				Computed:            true,
+			},
+			"api_keys": schema.ListNestedAttribute{
+				MarkdownDescription: "api keys of the account",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: apiKeysDataApiKeyAttr(),
+				},
+			},
+		},
+	}
+}
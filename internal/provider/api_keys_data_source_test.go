@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestApiKeysDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testApiKeysDataSource(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("data.neon_api_keys.test", "api_keys.*", map[string]string{
+						"name": "name_api_keys",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testApiKeysDataSource() string {
+	return `
+resource "neon_api_key" "test" {
+	name = "name_api_keys"
+}
+
+data "neon_api_keys" "test" {
+	depends_on = [neon_api_key.test]
+}
+`
+}
@@ -30,13 +30,13 @@ resource "neon_project" "test" {
 resource "neon_branch" "test" {
 	project_id = neon_project.test.id
 	name = "name_branch"
-	endpoints = [
-		{
+	endpoints = {
+		primary = {
 			type = "read_write"
 			autoscaling_limit_min_cu = 1
 			autoscaling_limit_max_cu = 1
 		}
-	]
+	}
 }
 
 data "neon_branch" "test" {
@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"sort"
 	"strings"
 
 	"github.com/go-resty/resty/v2"
@@ -12,6 +14,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
@@ -25,6 +30,12 @@ type branchResourceEndpointJSON struct {
 	AutoscalingLimitMinCu int64  `json:"autoscaling_limit_min_cu"`
 	AutoscalingLimitMaxCu int64  `json:"autoscaling_limit_max_cu"`
 }
+type branchResourceEndpointModel struct {
+	ID                    types.String `tfsdk:"id"`
+	Type                  types.String `tfsdk:"type"`
+	AutoscalingLimitMinCu types.Int64  `tfsdk:"autoscaling_limit_min_cu"`
+	AutoscalingLimitMaxCu types.Int64  `tfsdk:"autoscaling_limit_max_cu"`
+}
 type branchResourceJSON struct {
 	ID               string                 `json:"id"`
 	ProjectID        string                 `json:"project_id"`
@@ -57,20 +68,225 @@ func (in *branchResourceJSON) ToBranchResourceModel(ctx context.Context) (*Branc
 		LogicalSize:      types.Int64Value(in.LogicalSize),
 		LogicalSizeLimit: types.Int64Value(in.LogicalSizeLimit),
 		PhysicalSize:     types.Int64Value(in.PhysicalSize),
-		Endpoints:        types.ListNull(types.ObjectType{AttrTypes: typeFromAttrs(branchResourceEndpointAttr())}),
+		Endpoints:        types.MapNull(types.ObjectType{AttrTypes: typeFromAttrs(branchResourceEndpointAttr())}),
+	}
+	details, diags := branchEndpointDetails(ctx, in.Endpoints)
+	if diags.HasError() {
+		return nil, diags
 	}
-	if len(in.Endpoints) > 0 {
-		e := []endpointResourceModel{}
-		for _, v := range in.Endpoints {
-			e = append(e, *v.ToEndpointResourceModel())
+	branch.EndpointDetails = details
+	return branch, nil
+}
+
+// branchEndpointDetails returns the computed endpoint_details, sorted by type
+// then id as the api returns the endpoints in no particular order.
+func branchEndpointDetails(ctx context.Context, endpoints []endpointResourceJSON) (types.List, diag.Diagnostics) {
+	sorted := append([]endpointResourceJSON{}, endpoints...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Type != sorted[j].Type {
+			return sorted[i].Type < sorted[j].Type
 		}
-		aux, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: typeFromAttrs(branchResourceEndpointAttr())}, e)
-		if diags.HasError() {
-			return nil, diags
+		return sorted[i].Id < sorted[j].Id
+	})
+	e := []endpointResourceModel{}
+	for _, v := range sorted {
+		e = append(e, *v.ToEndpointResourceModel())
+	}
+	return types.ListValueFrom(ctx, types.ObjectType{AttrTypes: typeFromAttrs(branchEndpointDetailsAttr())}, e)
+}
+
+// branchEndpointsValue returns the endpoints attribute for the labelled
+// endpoints.
+func branchEndpointsValue(ctx context.Context, endpoints map[string]branchResourceEndpointModel) (types.Map, diag.Diagnostics) {
+	return types.MapValueFrom(ctx, types.ObjectType{AttrTypes: typeFromAttrs(branchResourceEndpointAttr())}, endpoints)
+}
+
+// importBranchEndpoints labels every endpoint of the branch with its id, when
+// no labels are known yet after an import.
+func importBranchEndpoints(endpoints []endpointResourceJSON) map[string]branchResourceEndpointModel {
+	labelled := map[string]branchResourceEndpointModel{}
+	for _, v := range endpoints {
+		labelled[v.Id] = branchResourceEndpointModel{
+			ID:                    types.StringValue(v.Id),
+			Type:                  types.StringValue(v.Type),
+			AutoscalingLimitMinCu: types.Int64Value(v.AutoscalingLimitMinCu),
+			AutoscalingLimitMaxCu: types.Int64Value(v.AutoscalingLimitMaxCu),
 		}
-		branch.Endpoints = aux
 	}
-	return branch, nil
+	return labelled
+}
+
+// refreshBranchEndpoints refreshes the labelled endpoints from the endpoints
+// read from the api, by id. The branch may have endpoints managed by
+// neon_endpoint or neon_read_replica, they are left out, and a labelled
+// endpoint that no longer exists is dropped.
+func refreshBranchEndpoints(labelled map[string]branchResourceEndpointModel, endpoints []endpointResourceJSON) map[string]branchResourceEndpointModel {
+	byID := map[string]endpointResourceJSON{}
+	for _, v := range endpoints {
+		byID[v.Id] = v
+	}
+	refreshed := map[string]branchResourceEndpointModel{}
+	for label, v := range labelled {
+		endpoint, ok := byID[v.ID.ValueString()]
+		if !ok {
+			continue
+		}
+		refreshed[label] = branchResourceEndpointModel{
+			ID:                    types.StringValue(endpoint.Id),
+			Type:                  types.StringValue(endpoint.Type),
+			AutoscalingLimitMinCu: types.Int64Value(endpoint.AutoscalingLimitMinCu),
+			AutoscalingLimitMaxCu: types.Int64Value(endpoint.AutoscalingLimitMaxCu),
+		}
+	}
+	return refreshed
+}
+
+// reconcileBranchEndpoints applies the planned endpoints of a branch and
+// returns them with their ids. Endpoints keeping their label and type are
+// patched in place, a removed endpoint is reused for an added one of the same
+// type so relabelling does not recreate it, the others are deleted or
+// created.
+func reconcileBranchEndpoints(client *resty.Client, projectID, branchID string, state, plan map[string]branchResourceEndpointModel) (map[string]branchResourceEndpointModel, error) {
+	applied := map[string]branchResourceEndpointModel{}
+	added := []string{}
+	removed := map[string]branchResourceEndpointModel{}
+	for label, v := range state {
+		if planned, ok := plan[label]; !ok || !planned.Type.Equal(v.Type) {
+			removed[label] = v
+		}
+	}
+	for _, label := range sortedEndpointLabels(plan) {
+		planned := plan[label]
+		current, ok := state[label]
+		if !ok || !planned.Type.Equal(current.Type) {
+			added = append(added, label)
+			continue
+		}
+		planned.ID = current.ID
+		if !planned.AutoscalingLimitMinCu.Equal(current.AutoscalingLimitMinCu) || !planned.AutoscalingLimitMaxCu.Equal(current.AutoscalingLimitMaxCu) {
+			if err := patchBranchEndpoint(client, projectID, branchID, planned); err != nil {
+				return nil, err
+			}
+		}
+		applied[label] = planned
+	}
+
+	created := []string{}
+	for _, label := range added {
+		planned := plan[label]
+		reused := ""
+		for _, removedLabel := range sortedEndpointLabels(removed) {
+			if removed[removedLabel].Type.Equal(planned.Type) {
+				reused = removedLabel
+				break
+			}
+		}
+		if reused == "" {
+			created = append(created, label)
+			continue
+		}
+		planned.ID = removed[reused].ID
+		delete(removed, reused)
+		if err := patchBranchEndpoint(client, projectID, branchID, planned); err != nil {
+			return nil, err
+		}
+		applied[label] = planned
+	}
+
+	// deleting first frees the read_write endpoint of the branch, there can
+	// only be one
+	for _, label := range sortedEndpointLabels(removed) {
+		response, err := client.R().Delete(fmt.Sprintf("/projects/%s/endpoints/%s", projectID, removed[label].ID.ValueString()))
+		if err != nil {
+			return nil, err
+		}
+		if response.IsError() && response.StatusCode() != http.StatusNotFound {
+			return nil, fmt.Errorf("failed to delete branch endpoint %s with a status code: %s", label, response.Status())
+		}
+	}
+	for _, label := range created {
+		planned := plan[label]
+		content := struct {
+			Endpoint createEndpoint `json:"endpoint"`
+		}{
+			Endpoint: createEndpoint{
+				Branch_id:                branchID,
+				Type:                     planned.Type.ValueString(),
+				Autoscaling_limit_min_cu: planned.AutoscalingLimitMinCu.ValueInt64(),
+				Autoscaling_limit_max_cu: planned.AutoscalingLimitMaxCu.ValueInt64(),
+			},
+		}
+		response, err := client.R().SetBody(content).Post(fmt.Sprintf("/projects/%s/endpoints", projectID))
+		if err != nil {
+			return nil, err
+		}
+		if response.IsError() {
+			return nil, fmt.Errorf("failed to create branch endpoint %s with a status code: %s", label, response.Status())
+		}
+		endpoint := struct {
+			Endpoint endpointResourceJSON `json:"endpoint"`
+		}{}
+		if err := json.Unmarshal(response.Body(), &endpoint); err != nil {
+			return nil, err
+		}
+		planned.ID = types.StringValue(endpoint.Endpoint.Id)
+		applied[label] = planned
+	}
+	return applied, nil
+}
+
+func patchBranchEndpoint(client *resty.Client, projectID, branchID string, endpoint branchResourceEndpointModel) error {
+	content := struct {
+		Endpoint updateEndpoint `json:"endpoint"`
+	}{
+		Endpoint: updateEndpoint{
+			Branch_id:                branchID,
+			Autoscaling_limit_min_cu: endpoint.AutoscalingLimitMinCu.ValueInt64(),
+			Autoscaling_limit_max_cu: endpoint.AutoscalingLimitMaxCu.ValueInt64(),
+		},
+	}
+	response, err := client.R().SetBody(content).Patch(fmt.Sprintf("/projects/%s/endpoints/%s", projectID, endpoint.ID.ValueString()))
+	if err != nil {
+		return err
+	}
+	if response.IsError() {
+		return fmt.Errorf("failed to update branch endpoint %s with a status code: %s", endpoint.ID.ValueString(), response.Status())
+	}
+	return nil
+}
+
+func sortedEndpointLabels(endpoints map[string]branchResourceEndpointModel) []string {
+	labels := []string{}
+	for label := range endpoints {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	return labels
+}
+
+// endpointIDPlanModifier keeps the id of a labelled branch endpoint from
+// state, unless its type changes and the endpoint is recreated.
+type endpointIDPlanModifier struct{}
+
+func (m endpointIDPlanModifier) Description(ctx context.Context) string {
+	return "Keeps the endpoint id while the endpoint type does not change."
+}
+
+func (m endpointIDPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m endpointIDPlanModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.StateValue.IsNull() || !req.PlanValue.IsUnknown() {
+		return
+	}
+	var planned, current types.String
+	typePath := req.Path.ParentPath().AtName("type")
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, typePath, &planned)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, typePath, &current)...)
+	if planned.Equal(current) {
+		resp.PlanValue = req.StateValue
+	}
 }
 
 func (in *BranchResourceModel) ToBranchResourceObject(ctx context.Context) (types.Object, diag.Diagnostics) {
@@ -91,11 +307,14 @@ type BranchResourceModel struct {
 	LogicalSize      types.Int64  `tfsdk:"logical_size"`
 	LogicalSizeLimit types.Int64  `tfsdk:"logical_size_limit"`
 	PhysicalSize     types.Int64  `tfsdk:"physical_size"`
-	Endpoints        types.List   `tfsdk:"endpoints"`
+	Endpoints        types.Map    `tfsdk:"endpoints"`
+	EndpointDetails  types.List   `tfsdk:"endpoint_details"`
 }
 
 var _ resource.Resource = branchResource{}
+var _ resource.ResourceWithUpgradeState = branchResource{}
 var _ resource.ResourceWithImportState = branchResource{}
+var _ resource.ResourceWithIdentity = branchResource{}
 
 func branchResourceAttr() map[string]schema.Attribute {
 	return map[string]schema.Attribute{
@@ -141,33 +360,36 @@ func branchResourceAttr() map[string]schema.Attribute {
 		"physical_size": schema.Int64Attribute{
 			Computed: true,
 		},
-		"endpoints": schema.ListNestedAttribute{
+		"endpoints": schema.MapNestedAttribute{
+			MarkdownDescription: "endpoints of the branch keyed by a label of your choice, updated, created and deleted in place. " +
+				"Endpoints managed by neon_endpoint or neon_read_replica are not part of it, leaving the attribute out keeps " +
+				"the endpoints as they are and `{}` deletes them. An import labels every endpoint of the branch with its id",
 			NestedObject: schema.NestedAttributeObject{
 				Attributes: branchResourceEndpointAttr(),
 			},
 			Optional: true,
+			Computed: true,
+		},
+		"endpoint_details": schema.ListNestedAttribute{
+			MarkdownDescription: "endpoints of the branch, sorted by type then id",
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: branchEndpointDetailsAttr(),
+			},
+			Computed: true,
 		},
 	}
 }
 
 func branchResourceEndpointAttr() map[string]schema.Attribute {
 	return map[string]schema.Attribute{
-		"host": schema.StringAttribute{
-			MarkdownDescription: "neon host",
-			Computed:            true,
-		},
 		"id": schema.StringAttribute{
 			MarkdownDescription: "endpoint id",
 			Computed:            true,
 		},
-		"project_id": schema.StringAttribute{
-			MarkdownDescription: "project id",
-			Computed:            true,
-		},
-		"branch_id": schema.StringAttribute{
-			MarkdownDescription: "postgres branch",
-			Computed:            true,
-			//PlanModifiers: planmodifier.str,
+		"type": schema.StringAttribute{
+			MarkdownDescription: "type",
+			Required:            true,
+			Validators:          []validator.String{stringvalidator.OneOf("read_write", "read_only")},
 		},
 		"autoscaling_limit_min_cu": schema.Int64Attribute{
 			MarkdownDescription: "autoscaling limit min",
@@ -177,61 +399,29 @@ func branchResourceEndpointAttr() map[string]schema.Attribute {
 			MarkdownDescription: "autoscaling limit max",
 			Required:            true,
 		},
-		"region_id": schema.StringAttribute{
-			MarkdownDescription: "region id",
-			Computed:            true,
-		},
-		"type": schema.StringAttribute{
-			MarkdownDescription: "type",
-			Required:            true,
-			Validators:          []validator.String{stringvalidator.OneOf("read_write", "read_only")},
-		},
-		"current_state": schema.StringAttribute{
-			MarkdownDescription: "current state",
-			Computed:            true,
-			Validators:          []validator.String{stringvalidator.OneOf("init", "active", "idle")},
-		},
-		"pending_state": schema.StringAttribute{
-			MarkdownDescription: "pending state",
-			Computed:            true,
-			Validators:          []validator.String{stringvalidator.OneOf("init", "active", "idle")},
-		},
-		"pooler_enabled": schema.BoolAttribute{
-			MarkdownDescription: "pooler enabled",
-			Computed:            true,
-		},
-		"pooler_mode": schema.StringAttribute{
-			MarkdownDescription: "pooler mode",
-			Computed:            true,
-			Validators:          []validator.String{stringvalidator.OneOf("transaction")},
-		},
-		"disabled": schema.BoolAttribute{
-			MarkdownDescription: "disabled",
-			Computed:            true,
-		},
-		"passwordless_access": schema.BoolAttribute{
-			MarkdownDescription: "passwordless access",
-			Computed:            true,
-		},
-		"last_active": schema.StringAttribute{
-			MarkdownDescription: "last active",
-			Computed:            true,
-		},
-		"created_at": schema.StringAttribute{
-			MarkdownDescription: "created at",
-			Computed:            true,
-		},
-		"updated_at": schema.StringAttribute{
-			MarkdownDescription: "updated at",
-			Computed:            true,
-		},
 	}
 }
 
+// branchEndpointDetailsAttr returns the endpoint attributes, all computed.
+func branchEndpointDetailsAttr() map[string]schema.Attribute {
+	attributes := map[string]schema.Attribute{}
+	for k, v := range endpointResourceAttr() {
+		switch a := v.(type) {
+		case schema.StringAttribute:
+			attributes[k] = schema.StringAttribute{MarkdownDescription: a.MarkdownDescription, Computed: true}
+		case schema.Int64Attribute:
+			attributes[k] = schema.Int64Attribute{MarkdownDescription: a.MarkdownDescription, Computed: true}
+		case schema.BoolAttribute:
+			attributes[k] = schema.BoolAttribute{MarkdownDescription: a.MarkdownDescription, Computed: true}
+		}
+	}
+	return attributes
+}
+
 func (v *BranchResourceModel) toBranchJSON(ctx context.Context) (*branchResourceJSON, diag.Diagnostics) {
 	endpoints := []endpointResourceJSON{}
-	if !v.Endpoints.IsNull() {
-		for _, vv := range v.Endpoints.Elements() {
+	if !v.EndpointDetails.IsNull() {
+		for _, vv := range v.EndpointDetails.Elements() {
 			endpointModel := endpointResourceModel{}
 			diags := vv.(types.Object).As(ctx, &endpointModel, basetypes.ObjectAsOptions{})
 			if diags.HasError() {
@@ -260,8 +450,26 @@ func (v *BranchResourceModel) toBranchJSON(ctx context.Context) (*branchResource
 }
 
 func (r branchResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := branchResourceAttr()
+	addStringPlanModifiers(attributes, "project_id", stringplanmodifier.RequiresReplace())
+	addMapNestedPlanModifiers(attributes, "endpoints", mapplanmodifier.UseStateForUnknown())
+	endpoints := attributes["endpoints"].(schema.MapNestedAttribute)
+	id := endpoints.NestedObject.Attributes["id"].(schema.StringAttribute)
+	id.PlanModifiers = []planmodifier.String{endpointIDPlanModifier{}}
+	endpoints.NestedObject.Attributes["id"] = id
+	for _, k := range []string{"id", "name", "parent_id", "parent_lsn", "parent_timestamp", "created_at"} {
+		addStringPlanModifiers(attributes, k, stringplanmodifier.UseStateForUnknown())
+	}
 	resp.Schema = schema.Schema{
-		Attributes: branchResourceAttr(),
+		Version:    2,
+		Attributes: attributes,
+	}
+}
+
+func (r branchResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: upgradeRawState(r, upgradeBranchEndpoints),
+		1: upgradeRawState(r, upgradeBranchEndpoints),
 	}
 }
 
@@ -293,13 +501,18 @@ func (r branchResource) Create(ctx context.Context, req resource.CreateRequest,
 		Endpoints: []branchResourceEndpointJSON{},
 	}
 
-	for _, vv := range data.Endpoints.Elements() {
-		endpoint := endpointResourceModel{}
-		diags := vv.(types.Object).As(ctx, &endpoint, basetypes.ObjectAsOptions{})
+	// the created endpoints are returned in the order of the labels
+	planned := map[string]branchResourceEndpointModel{}
+	if !data.Endpoints.IsUnknown() {
+		diags = data.Endpoints.ElementsAs(ctx, &planned, false)
 		resp.Diagnostics.Append(diags...)
 		if resp.Diagnostics.HasError() {
 			return
 		}
+	}
+	labels := sortedEndpointLabels(planned)
+	for _, label := range labels {
+		endpoint := planned[label]
 		content.Endpoints = append(content.Endpoints, branchResourceEndpointJSON{
 			Type:                  endpoint.Type.ValueString(),
 			AutoscalingLimitMinCu: endpoint.AutoscalingLimitMinCu.ValueInt64(),
@@ -330,8 +543,25 @@ func (r branchResource) Create(ctx context.Context, req resource.CreateRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	if data.Endpoints.IsUnknown() {
+		planned = importBranchEndpoints(branchJSON.Endpoints)
+	} else if len(branchJSON.Endpoints) != len(labels) {
+		resp.Diagnostics.AddError("Failed to create branch endpoints", fmt.Sprintf("%d endpoints were created, want %d", len(branchJSON.Endpoints), len(labels)))
+		return
+	}
+	for i, label := range labels {
+		endpoint := planned[label]
+		endpoint.ID = types.StringValue(branchJSON.Endpoints[i].Id)
+		planned[label] = endpoint
+	}
+	branchObj.Endpoints, diags = branchEndpointsValue(ctx, planned)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	diags = resp.State.Set(ctx, branchObj)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.State, resp.Identity)...)
 }
 
 func (r branchResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -397,21 +627,32 @@ func (r branchResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		resp.Diagnostics.AddError("Failed to unmarshal response", err.Error())
 		return
 	}
-	if len(bodyEndpoints.Endpoints) > 0 {
-		e := []endpointResourceModel{}
-		for _, v := range bodyEndpoints.Endpoints {
-			e = append(e, *v.ToEndpointResourceModel())
-		}
-		aux, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: typeFromAttrs(branchResourceEndpointAttr())}, e)
+	branchObj.EndpointDetails, diags = branchEndpointDetails(ctx, bodyEndpoints.Endpoints)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// without labels in state, after an import, every endpoint is labelled
+	// with its id
+	labelled := importBranchEndpoints(bodyEndpoints.Endpoints)
+	if !data.Endpoints.IsNull() && !data.Endpoints.IsUnknown() {
+		current := map[string]branchResourceEndpointModel{}
+		diags = data.Endpoints.ElementsAs(ctx, &current, false)
 		resp.Diagnostics.Append(diags...)
 		if resp.Diagnostics.HasError() {
 			return
 		}
-		branchObj.Endpoints = aux
+		labelled = refreshBranchEndpoints(current, bodyEndpoints.Endpoints)
+	}
+	branchObj.Endpoints, diags = branchEndpointsValue(ctx, labelled)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	diags = resp.State.Set(ctx, branchObj)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.State, resp.Identity)...)
 }
 
 func (r branchResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -460,6 +701,26 @@ func (r branchResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	var applied map[string]branchResourceEndpointModel
+	if !data.Endpoints.IsUnknown() {
+		current := map[string]branchResourceEndpointModel{}
+		if !state.Endpoints.IsNull() {
+			diags = state.Endpoints.ElementsAs(ctx, &current, false)
+			resp.Diagnostics.Append(diags...)
+		}
+		planned := map[string]branchResourceEndpointModel{}
+		diags = data.Endpoints.ElementsAs(ctx, &planned, false)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		applied, err = reconcileBranchEndpoints(r.client, state.ProjectID.ValueString(), state.ID.ValueString(), current, planned)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to update branch endpoints", err.Error())
+			return
+		}
+	}
+
 	response, _ = r.client.R().Get(fmt.Sprintf("/projects/%s/branches/%s/endpoints", state.ProjectID.ValueString(), state.ID.ValueString()))
 	if response.IsError() {
 		resp.Diagnostics.AddError(fmt.Sprintf("Failed to delete branch resource with a status code: %s", response.Status()), "")
@@ -473,30 +734,50 @@ func (r branchResource) Update(ctx context.Context, req resource.UpdateRequest,
 		resp.Diagnostics.AddError("Failed to unmarshal response", err.Error())
 		return
 	}
-	if len(bodyEndpoints.Endpoints) > 0 {
-		e := []endpointResourceModel{}
-		for _, v := range bodyEndpoints.Endpoints {
-			e = append(e, *v.ToEndpointResourceModel())
-		}
-		aux, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: typeFromAttrs(branchResourceEndpointAttr())}, e)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		branchModel.Endpoints = aux
+	branchModel.EndpointDetails, diags = branchEndpointDetails(ctx, bodyEndpoints.Endpoints)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if applied == nil {
+		applied = importBranchEndpoints(bodyEndpoints.Endpoints)
+	}
+	branchModel.Endpoints, diags = branchEndpointsValue(ctx, applied)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 	diags = resp.State.Set(ctx, branchModel)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.State, resp.Identity)...)
+}
+
+func (r branchResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identitySchema("project_id", "id")
 }
 
 func (r branchResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	ids := strings.Split(req.ID, "/")
-	if len(ids) != 2 || ids[0] == "" || ids[1] == "" {
-		resp.Diagnostics.AddError(
-			"Cannot import branch",
-			fmt.Sprintf("Invalid id '%s' specified. should be in format \"id/project_id\"", req.ID),
-		)
-	}
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), ids[0])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), ids[1])...)
+	if req.ID == "" {
+		resp.Diagnostics.Append(importIdentity(ctx, req.Identity, &resp.State)...)
+		return
+	}
+	attributes, err := parseBranchImportID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Cannot import branch", err.Error())
+		return
+	}
+	setImportAttributes(ctx, attributes, resp)
+}
+
+// parseBranchImportID parses "project_id/id", or the previous "id/project_id"
+// format. Both have two parts, the previous format is only used when the id
+// starts with br-, the prefix of branch ids that project ids never have.
+func parseBranchImportID(id string) (map[string]string, error) {
+	if len(strings.Split(id, "/")) != 2 {
+		return parseImportID(id, "project_id/id", "id/project_id")
+	}
+	if strings.HasPrefix(id, "br-") {
+		return parseImportID(id, "id/project_id")
+	}
+	return parseImportID(id, "project_id/id")
 }
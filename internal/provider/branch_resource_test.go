@@ -1,14 +1,22 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestBranchResource(t *testing.T) {
+	var id string
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
@@ -19,6 +27,13 @@ func TestBranchResource(t *testing.T) {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("neon_project.test", "name", "name_project"),
 					resource.TestCheckResourceAttr("neon_branch.test", "name", "name_branch"),
+					resource.TestCheckResourceAttr("neon_branch.test", "endpoints.%", "1"),
+					resource.TestCheckResourceAttr("neon_branch.test", "endpoint_details.#", "1"),
+					resource.TestCheckResourceAttr("neon_branch.test", "endpoints.primary.type", "read_write"),
+					resource.TestCheckResourceAttrSet("neon_branch.test", "endpoints.primary.id"),
+					resource.TestCheckResourceAttr("neon_branch.test", "endpoint_details.0.type", "read_write"),
+					resource.TestCheckResourceAttrSet("neon_branch.test", "endpoint_details.0.host"),
+					testAccCheckResourceID("neon_branch.test", &id),
 				),
 			},
 			// ImportState testing
@@ -33,11 +48,46 @@ func TestBranchResource(t *testing.T) {
 					return "", fmt.Errorf("cannot find neon_branch.test")
 				},
 			},
+			{
+				ResourceName:      "neon_branch.test",
+				ImportState:       true,
+				ImportStateVerify: false,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					if rs, ok := s.RootModule().Resources["neon_branch.test"]; ok {
+						return fmt.Sprintf("%s/%s", rs.Primary.Attributes["project_id"], rs.Primary.ID), nil
+					}
+					return "", fmt.Errorf("cannot find neon_branch.test")
+				},
+			},
 			// Update and Read testing
 			{
 				Config: testBranchResourceUpdate(),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("neon_branch.test", "name", "name_branch_updated"),
+					testAccCheckResourceReplaced("neon_branch.test", &id, false),
+				),
+			},
+			// Changing the endpoints updates them in place, two identical read
+			// only endpoints are kept apart by their labels
+			{
+				Config: testBranchResourceEndpoints(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_branch.test", "endpoints.%", "3"),
+					resource.TestCheckResourceAttr("neon_branch.test", "endpoint_details.#", "3"),
+					resource.TestCheckResourceAttr("neon_branch.test", "endpoints.primary.autoscaling_limit_max_cu", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("neon_branch.test", "endpoint_details.*", map[string]string{
+						"type":                     "read_write",
+						"autoscaling_limit_max_cu": "2",
+					}),
+					testAccCheckResourceReplaced("neon_branch.test", &id, false),
+				),
+			},
+			// Moving the branch to another project replaces it
+			{
+				Config: testBranchResourceMove(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("neon_branch.test", "project_id", "neon_project.other", "id"),
+					testAccCheckResourceReplaced("neon_branch.test", &id, true),
 				),
 			},
 		},
@@ -53,13 +103,13 @@ resource "neon_project" "test" {
 resource "neon_branch" "test" {
 	project_id = neon_project.test.id
 	name = "name_branch"
-	endpoints = [
-		{
+	endpoints = {
+		primary = {
 			type = "read_write"
 			autoscaling_limit_min_cu = 1
 			autoscaling_limit_max_cu = 1
 		}
-	]
+	}
 }
 `
 }
@@ -73,13 +123,205 @@ resource "neon_project" "test" {
 resource "neon_branch" "test" {
 	project_id = neon_project.test.id
 	name = "name_branch_updated"
-	endpoints = [
-		{
+	endpoints = {
+		primary = {
+			type = "read_write"
+			autoscaling_limit_min_cu = 1
+			autoscaling_limit_max_cu = 1
+		}
+	}
+}
+`
+}
+
+func testBranchResourceEndpoints() string {
+	return `
+resource "neon_project" "test" {
+	name = "name_project"
+}
+
+resource "neon_branch" "test" {
+	project_id = neon_project.test.id
+	name = "name_branch_updated"
+	endpoints = {
+		primary = {
+			type = "read_write"
+			autoscaling_limit_min_cu = 1
+			autoscaling_limit_max_cu = 2
+		}
+		reports = {
+			type = "read_only"
+			autoscaling_limit_min_cu = 1
+			autoscaling_limit_max_cu = 1
+		}
+		dashboards = {
+			type = "read_only"
+			autoscaling_limit_min_cu = 1
+			autoscaling_limit_max_cu = 1
+		}
+	}
+}
+`
+}
+
+func testBranchResourceMove() string {
+	return `
+resource "neon_project" "test" {
+	name = "name_project"
+}
+
+resource "neon_project" "other" {
+	name = "name_project_other"
+}
+
+resource "neon_branch" "test" {
+	project_id = neon_project.other.id
+	name = "name_branch_updated"
+	endpoints = {
+		primary = {
 			type = "read_write"
 			autoscaling_limit_min_cu = 1
 			autoscaling_limit_max_cu = 1
 		}
-	]
+	}
 }
 `
 }
+
+func TestBranchEndpointDetails(t *testing.T) {
+	details, diags := branchEndpointDetails(context.Background(), []endpointResourceJSON{
+		{Id: "ep-b", Type: "read_write"},
+		{Id: "ep-c", Type: "read_only"},
+		{Id: "ep-a", Type: "read_only"},
+	})
+	if diags.HasError() {
+		t.Fatalf("branchEndpointDetails() = %v", diags)
+	}
+	endpoints := []endpointResourceModel{}
+	diags = details.ElementsAs(context.Background(), &endpoints, false)
+	if diags.HasError() {
+		t.Fatalf("branchEndpointDetails() elements = %v", diags)
+	}
+	ids := []string{}
+	for _, v := range endpoints {
+		ids = append(ids, v.Id.ValueString())
+	}
+	if fmt.Sprint(ids) != "[ep-a ep-c ep-b]" {
+		t.Errorf("branchEndpointDetails() ids = %v, want [ep-a ep-c ep-b]", ids)
+	}
+}
+
+// endpointsString formats labelled endpoints as "label id type min-max".
+func endpointsString(endpoints map[string]branchResourceEndpointModel) string {
+	got := []string{}
+	for _, label := range sortedEndpointLabels(endpoints) {
+		e := endpoints[label]
+		got = append(got, fmt.Sprintf("%s %s %s %d-%d", label, e.ID.ValueString(), e.Type.ValueString(), e.AutoscalingLimitMinCu.ValueInt64(), e.AutoscalingLimitMaxCu.ValueInt64()))
+	}
+	return fmt.Sprint(got)
+}
+
+func TestBranchEndpoints(t *testing.T) {
+	labelled := map[string]branchResourceEndpointModel{
+		"primary": {ID: types.StringValue("ep-2"), Type: types.StringValue("read_write"), AutoscalingLimitMinCu: types.Int64Value(1), AutoscalingLimitMaxCu: types.Int64Value(1)},
+		"replica": {ID: types.StringValue("ep-3"), Type: types.StringValue("read_only"), AutoscalingLimitMinCu: types.Int64Value(1), AutoscalingLimitMaxCu: types.Int64Value(2)},
+	}
+	for _, v := range []struct {
+		name      string
+		endpoints []endpointResourceJSON
+		want      string
+	}{
+		// a read replica managed elsewhere is left out
+		{"extra endpoints", []endpointResourceJSON{
+			{Id: "ep-1", Type: "read_only", AutoscalingLimitMinCu: 2, AutoscalingLimitMaxCu: 4},
+			{Id: "ep-2", Type: "read_write", AutoscalingLimitMinCu: 1, AutoscalingLimitMaxCu: 1},
+			{Id: "ep-3", Type: "read_only", AutoscalingLimitMinCu: 1, AutoscalingLimitMaxCu: 2},
+		}, "[primary ep-2 read_write 1-1 replica ep-3 read_only 1-2]"},
+		{"changed limits", []endpointResourceJSON{
+			{Id: "ep-2", Type: "read_write", AutoscalingLimitMinCu: 1, AutoscalingLimitMaxCu: 3},
+			{Id: "ep-3", Type: "read_only", AutoscalingLimitMinCu: 1, AutoscalingLimitMaxCu: 2},
+		}, "[primary ep-2 read_write 1-3 replica ep-3 read_only 1-2]"},
+		{"deleted endpoint", []endpointResourceJSON{
+			{Id: "ep-2", Type: "read_write", AutoscalingLimitMinCu: 1, AutoscalingLimitMaxCu: 1},
+		}, "[primary ep-2 read_write 1-1]"},
+	} {
+		if got := endpointsString(refreshBranchEndpoints(labelled, v.endpoints)); got != v.want {
+			t.Errorf("%s: refreshBranchEndpoints() = %s, want %s", v.name, got, v.want)
+		}
+	}
+
+	// an import labels every endpoint with its id, two identical read only
+	// endpoints stay apart
+	imported := importBranchEndpoints([]endpointResourceJSON{
+		{Id: "ep-1", Type: "read_only", AutoscalingLimitMinCu: 1, AutoscalingLimitMaxCu: 1},
+		{Id: "ep-2", Type: "read_only", AutoscalingLimitMinCu: 1, AutoscalingLimitMaxCu: 1},
+	})
+	if got, want := endpointsString(imported), "[ep-1 ep-1 read_only 1-1 ep-2 ep-2 read_only 1-1]"; got != want {
+		t.Errorf("importBranchEndpoints() = %s, want %s", got, want)
+	}
+}
+
+func TestReconcileBranchEndpoints(t *testing.T) {
+	endpoint := func(id, endpointType string, maxCu int64) branchResourceEndpointModel {
+		return branchResourceEndpointModel{
+			ID:                    types.StringValue(id),
+			Type:                  types.StringValue(endpointType),
+			AutoscalingLimitMinCu: types.Int64Value(1),
+			AutoscalingLimitMaxCu: types.Int64Value(maxCu),
+		}
+	}
+	state := map[string]branchResourceEndpointModel{
+		"primary":  endpoint("ep-1", "read_write", 1),
+		"replica":  endpoint("ep-2", "read_only", 1),
+		"reports":  endpoint("ep-3", "read_only", 1),
+		"analysis": endpoint("ep-4", "read_only", 1),
+	}
+	plan := map[string]branchResourceEndpointModel{
+		// the limits change in place
+		"primary": endpoint("", "read_write", 2),
+		// unchanged
+		"replica": endpoint("", "read_only", 1),
+		// analysis and reports are removed, the added read only endpoint
+		// reuses the first one and the other is deleted
+		"dashboards": endpoint("", "read_only", 1),
+		// a removed read only endpoint can not be reused, it is created
+		"writer": endpoint("", "read_write", 1),
+	}
+	// the same endpoint of another type is deleted and created again
+	state["typed"] = endpoint("ep-5", "read_only", 1)
+	plan["typed"] = endpoint("", "read_write", 1)
+
+	requests := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == http.MethodPatch:
+			if !strings.Contains(string(body), `"branch_id":"br-1"`) {
+				http.Error(w, "no branch id", http.StatusBadRequest)
+				return
+			}
+			fmt.Fprint(w, `{"endpoint":{}}`)
+		case r.Method == http.MethodDelete && r.URL.Path == "/projects/p-1/endpoints/ep-3":
+			http.Error(w, "not found", http.StatusNotFound)
+		case r.Method == http.MethodDelete:
+			fmt.Fprint(w, `{"endpoint":{}}`)
+		case r.Method == http.MethodPost:
+			fmt.Fprintf(w, `{"endpoint":{"id":"ep-new-%d"}}`, len(requests))
+		}
+	}))
+	defer server.Close()
+
+	applied, err := reconcileBranchEndpoints(resty.New().SetBaseURL(server.URL), "p-1", "br-1", state, plan)
+	if err != nil {
+		t.Fatalf("reconcileBranchEndpoints() error = %s", err)
+	}
+	wantRequests := "[PATCH /projects/p-1/endpoints/ep-1 PATCH /projects/p-1/endpoints/ep-4 DELETE /projects/p-1/endpoints/ep-3 DELETE /projects/p-1/endpoints/ep-5 POST /projects/p-1/endpoints POST /projects/p-1/endpoints]"
+	if fmt.Sprint(requests) != wantRequests {
+		t.Errorf("reconcileBranchEndpoints() requests = %v, want %s", requests, wantRequests)
+	}
+	want := "[dashboards ep-4 read_only 1-1 primary ep-1 read_write 1-2 replica ep-2 read_only 1-1 typed ep-new-5 read_write 1-1 writer ep-new-6 read_write 1-1]"
+	if got := endpointsString(applied); got != want {
+		t.Errorf("reconcileBranchEndpoints() = %s, want %s", got, want)
+	}
+}
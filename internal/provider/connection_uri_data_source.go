@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &connectionURIDataSource{}
+
+type connectionURIDataSource struct {
+	client *resty.Client
+}
+
+type connectionURIDataModel struct {
+	ID           types.String `tfsdk:"id"`
+	ProjectID    types.String `tfsdk:"project_id"`
+	BranchID     types.String `tfsdk:"branch_id"`
+	EndpointID   types.String `tfsdk:"endpoint_id"`
+	DatabaseName types.String `tfsdk:"database_name"`
+	RoleName     types.String `tfsdk:"role_name"`
+	Pooled       types.Bool   `tfsdk:"pooled"`
+	URI          types.String `tfsdk:"uri"`
+	Host         types.String `tfsdk:"host"`
+	Port         types.Int64  `tfsdk:"port"`
+	Database     types.String `tfsdk:"database"`
+	User         types.String `tfsdk:"user"`
+	Password     types.String `tfsdk:"password"`
+}
+
+// getConnectionURI asks Neon to build a connection uri for the given role and
+// database. branchID and endpointID may be empty to use the project defaults.
+func getConnectionURI(client *resty.Client, projectID, branchID, endpointID, databaseName, roleName string, pooled bool) (string, error) {
+	request := client.R().
+		SetQueryParam("database_name", databaseName).
+		SetQueryParam("role_name", roleName).
+		SetQueryParam("pooled", strconv.FormatBool(pooled))
+	if branchID != "" {
+		request.SetQueryParam("branch_id", branchID)
+	}
+	if endpointID != "" {
+		request.SetQueryParam("endpoint_id", endpointID)
+	}
+	response, err := request.Get(fmt.Sprintf("/projects/%s/connection_uri", projectID))
+	if err != nil {
+		return "", err
+	}
+	if response.IsError() {
+		return "", fmt.Errorf("failed to get connection uri with a status code: %s", response.Status())
+	}
+	inner := struct {
+		URI string `json:"uri"`
+	}{}
+	err = json.Unmarshal(response.Body(), &inner)
+	if err != nil {
+		return "", err
+	}
+	return inner.URI, nil
+}
+
+// Metadata implements datasource.DataSource
+func (*connectionURIDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_connection_uri"
+}
+
+// Read implements datasource.DataSource
+func (d *connectionURIDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data connectionURIDataModel
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	uri, err := getConnectionURI(
+		d.client,
+		data.ProjectID.ValueString(),
+		data.BranchID.ValueString(),
+		data.EndpointID.ValueString(),
+		data.DatabaseName.ValueString(),
+		data.RoleName.ValueString(),
+		data.Pooled.ValueBool(),
+	)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get connection uri", err.Error())
+		return
+	}
+	parts, err := (&projectConnUrisJSON{ConnectionURI: uri}).toProjectConnUrisModel()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to parse connection uri", err.Error())
+		return
+	}
+
+	data.ID = types.StringValue(fmt.Sprintf("%s/%s/%s", data.ProjectID.ValueString(), data.DatabaseName.ValueString(), data.RoleName.ValueString()))
+	data.URI = parts.ConnectionURI
+	data.Host = parts.Host
+	data.Port = parts.Port
+	data.Database = parts.Database
+	data.User = parts.User
+	data.Password = parts.Password
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Schema implements datasource.DataSource
+func (*connectionURIDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "placeholder identifier",
+				Computed:            true,
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "project id",
+				Required:            true,
+			},
+			"branch_id": schema.StringAttribute{
+				MarkdownDescription: "branch id, defaults to the project's default branch",
+				Optional:            true,
+			},
+			"endpoint_id": schema.StringAttribute{
+				MarkdownDescription: "endpoint id, defaults to the branch's read_write endpoint",
+				Optional:            true,
+			},
+			"database_name": schema.StringAttribute{
+				MarkdownDescription: "database name",
+				Required:            true,
+			},
+			"role_name": schema.StringAttribute{
+				MarkdownDescription: "role name",
+				Required:            true,
+			},
+			"pooled": schema.BoolAttribute{
+				MarkdownDescription: "connect through the connection pooler",
+				Optional:            true,
+			},
+			"uri": schema.StringAttribute{
+				MarkdownDescription: "connection uri",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"host": schema.StringAttribute{
+				MarkdownDescription: "host",
+				Computed:            true,
+			},
+			"port": schema.Int64Attribute{
+				MarkdownDescription: "port",
+				Computed:            true,
+			},
+			"database": schema.StringAttribute{
+				MarkdownDescription: "database name",
+				Computed:            true,
+			},
+			"user": schema.StringAttribute{
+				MarkdownDescription: "role name",
+				Computed:            true,
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "role password",
+				Computed:            true,
+				Sensitive:           true,
+			},
+		},
+	}
+}
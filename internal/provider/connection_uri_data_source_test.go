@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestConnectionURIDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testConnectionURIDataSource(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.neon_connection_uri.direct", "user", "name_role"),
+					resource.TestCheckResourceAttr("data.neon_connection_uri.direct", "database", "name_database"),
+					resource.TestCheckResourceAttrSet("data.neon_connection_uri.direct", "password"),
+					resource.TestCheckResourceAttrPair("data.neon_connection_uri.direct", "password", "neon_role.test", "password"),
+					resource.TestMatchResourceAttr("data.neon_connection_uri.pooled", "host", regexp.MustCompile("-pooler\\.")),
+				),
+			},
+		},
+	})
+}
+
+func testConnectionURIDataSource() string {
+	return `
+resource "neon_project" "test" {
+	name = "name_project"
+}
+
+resource "neon_role" "test" {
+	project_id = neon_project.test.id
+	branch_id = neon_project.test.branch.id
+	name = "name_role"
+}
+
+resource "neon_database" "test" {
+	project_id = neon_project.test.id
+	branch_id = neon_project.test.branch.id
+	name = "name_database"
+	owner_name = neon_role.test.name
+}
+
+data "neon_connection_uri" "direct" {
+	project_id = neon_project.test.id
+	branch_id = neon_project.test.branch.id
+	database_name = neon_database.test.name
+	role_name = neon_role.test.name
+}
+
+data "neon_connection_uri" "pooled" {
+	project_id = neon_project.test.id
+	database_name = neon_database.test.name
+	role_name = neon_role.test.name
+	pooled = true
+}
+`
+}
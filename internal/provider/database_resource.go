@@ -4,13 +4,16 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/go-resty/resty/v2"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -37,7 +40,9 @@ type databaseResourceModel struct {
 }
 
 var _ resource.Resource = databaseResource{}
+var _ resource.ResourceWithUpgradeState = databaseResource{}
 var _ resource.ResourceWithImportState = databaseResource{}
+var _ resource.ResourceWithIdentity = databaseResource{}
 
 func databaseResourceAttr() map[string]schema.Attribute {
 	return map[string]schema.Attribute{
@@ -91,11 +96,71 @@ func (in *databaseResourceJSON) toDatabaseModel(ctx context.Context, projectID s
 }
 
 func (r databaseResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	// The nested project databases keep the plain attributes since they are
+	// computed.
+	attributes := databaseResourceAttr()
+	attributes["id"] = schema.Int64Attribute{
+		Computed:      true,
+		PlanModifiers: []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
+	}
+	attributes["branch_id"] = schema.StringAttribute{
+		Required:      true,
+		PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+	}
+	attributes["project_id"] = schema.StringAttribute{
+		Required:      true,
+		PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+	}
+	attributes["name"] = schema.StringAttribute{
+		MarkdownDescription: "database name, changing it renames the database",
+		Required:            true,
+	}
+	attributes["owner_name"] = schema.StringAttribute{
+		MarkdownDescription: "role owning the database, defaults to the role Neon picks. Changing it transfers the ownership",
+		Optional:            true,
+		Computed:            true,
+		PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+	}
+	attributes["created_at"] = schema.StringAttribute{
+		Computed:      true,
+		PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+	}
 	resp.Schema = schema.Schema{
-		Attributes: databaseResourceAttr(),
+		Version:    1,
+		Attributes: attributes,
+	}
+}
+
+func (r databaseResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: upgradeFromV0(r),
 	}
 }
 
+// apiErrorMessage returns the message of a Neon api error response, or an
+// empty string when the body is not an api error.
+func apiErrorMessage(response *resty.Response) string {
+	inner := struct {
+		Message string `json:"message"`
+	}{}
+	if err := json.Unmarshal(response.Body(), &inner); err != nil {
+		return ""
+	}
+	return inner.Message
+}
+
+// databaseInUse tells whether a database could not be dropped because of its
+// active connections. The api passes on the postgres object_in_use error,
+// "database ... is being accessed by other users"; a conflict mentioning
+// active connections is read the same way.
+func databaseInUse(response *resty.Response) bool {
+	message := strings.ToLower(apiErrorMessage(response))
+	if strings.Contains(message, "is being accessed by other users") {
+		return true
+	}
+	return response.StatusCode() == http.StatusConflict && strings.Contains(message, "active connections")
+}
+
 func (r databaseResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data databaseResourceModel
 	diags := req.Plan.Get(ctx, &data)
@@ -144,6 +209,7 @@ func (r databaseResource) Create(ctx context.Context, req resource.CreateRequest
 	}
 	diags = resp.State.Set(ctx, databaseObj)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.State, resp.Identity)...)
 }
 
 func (r databaseResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -155,7 +221,15 @@ func (r databaseResource) Delete(ctx context.Context, req resource.DeleteRequest
 	}
 	response, _ := r.client.R().Delete(fmt.Sprintf("/projects/%s/branches/%s/databases/%s", data.ProjectID.ValueString(), data.BranchID.ValueString(), data.Name.ValueString()))
 	if response.IsError() {
-		resp.Diagnostics.AddError(fmt.Sprintf("Failed to delete branch resource with a status code: %s", response.Status()), "")
+		message := apiErrorMessage(response)
+		if databaseInUse(response) {
+			resp.Diagnostics.AddError(
+				fmt.Sprintf("Cannot delete database %s with active connections", data.Name.ValueString()),
+				"Close the connections to the database, e.g. stop the applications using it or run pg_terminate_backend, and apply again. "+message,
+			)
+			return
+		}
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to delete database resource with a status code: %s", response.Status()), message)
 		return
 	}
 	resp.State.RemoveResource(ctx)
@@ -163,6 +237,8 @@ func (r databaseResource) Delete(ctx context.Context, req resource.DeleteRequest
 
 func (r databaseResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_database"
+	// databases are renamed in place, changing their identity
+	resp.ResourceBehavior.MutableIdentity = true
 }
 
 func (r databaseResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -194,6 +270,12 @@ func (r databaseResource) Read(ctx context.Context, req resource.ReadRequest, re
 	}
 	diags = resp.State.Set(ctx, databaseObj)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.State, resp.Identity)...)
+}
+
+type updateDatabase struct {
+	Name      string `json:"name,omitempty"`
+	OwnerName string `json:"owner_name,omitempty"`
 }
 
 func (r databaseResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -210,25 +292,25 @@ func (r databaseResource) Update(ctx context.Context, req resource.UpdateRequest
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	// The database is addressed by its old name, only the renamed name and
+	// the new owner are sent.
+	update := updateDatabase{}
+	if !data.Name.Equal(state.Name) {
+		update.Name = data.Name.ValueString()
+	}
+	if !data.OwnerName.IsUnknown() && !data.OwnerName.Equal(state.OwnerName) {
+		update.OwnerName = data.OwnerName.ValueString()
+	}
 	content := struct {
-		Database struct {
-			Name      string `json:"name"`
-			OwnerName string `json:"owner_name,omitempty"`
-		} `json:"database"`
+		Database updateDatabase `json:"database"`
 	}{
-		Database: struct {
-			Name      string `json:"name"`
-			OwnerName string `json:"owner_name,omitempty"`
-		}{
-			Name:      data.Name.ValueString(),
-			OwnerName: data.OwnerName.ValueString(),
-		},
+		Database: update,
 	}
 	response, _ := r.client.R().
 		SetBody(content).
 		Patch(fmt.Sprintf("/projects/%s/branches/%s/databases/%s", state.ProjectID.ValueString(), state.BranchID.ValueString(), state.Name.ValueString()))
 	if response.IsError() {
-		resp.Diagnostics.AddError(fmt.Sprintf("Failed to create endpoint resource with a status code: %s", response.Status()), "")
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to update database resource with a status code: %s", response.Status()), apiErrorMessage(response))
 		return
 	}
 
@@ -249,17 +331,13 @@ func (r databaseResource) Update(ctx context.Context, req resource.UpdateRequest
 	}
 	diags = resp.State.Set(ctx, databaseObj)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.State, resp.Identity)...)
+}
+
+func (r databaseResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identitySchema("project_id", "branch_id", "name")
 }
 
 func (r databaseResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	ids := strings.Split(req.ID, "/")
-	if len(ids) != 3 || ids[0] == "" || ids[1] == "" || ids[2] == "" {
-		resp.Diagnostics.AddError(
-			"Cannot import branch",
-			fmt.Sprintf("Invalid id '%s' specified. should be in format \"project_id/branch_id/name\"", req.ID),
-		)
-	}
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), ids[0])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("branch_id"), ids[1])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), ids[2])...)
+	importState(ctx, req, resp, "database", "project_id/branch_id/name")
 }
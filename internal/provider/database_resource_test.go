@@ -2,8 +2,13 @@ package provider
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/go-resty/resty/v2"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
@@ -20,6 +25,7 @@ func TestDatabaseResource(t *testing.T) {
 					resource.TestCheckResourceAttr("neon_project.test", "name", "name_project"),
 					resource.TestCheckResourceAttr("neon_branch.test", "name", "name_branch"),
 					resource.TestCheckResourceAttr("neon_database.test", "name", "name_database"),
+					resource.TestCheckResourceAttrSet("neon_database.test", "owner_name"),
 				),
 			},
 			// ImportState testing
@@ -41,6 +47,14 @@ func TestDatabaseResource(t *testing.T) {
 					resource.TestCheckResourceAttr("neon_database.test", "name", "name_database_updated"),
 				),
 			},
+			// Owner transfer testing
+			{
+				Config: testDatabaseOwnerResource(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_database.test", "name", "name_database_updated"),
+					resource.TestCheckResourceAttrPair("neon_database.test", "owner_name", "neon_role.test", "name"),
+				),
+			},
 		},
 	})
 }
@@ -54,13 +68,13 @@ resource "neon_project" "test" {
 resource "neon_branch" "test" {
 	project_id = neon_project.test.id
 	name = "name_branch"
-	endpoints = [
-		{
+	endpoints = {
+		primary = {
 			type = "read_write"
 			autoscaling_limit_min_cu = 1
 			autoscaling_limit_max_cu = 1
 		}
-	]
+	}
 }
 
 resource "neon_database" "test" {
@@ -82,13 +96,13 @@ resource "neon_project" "test" {
 resource "neon_branch" "test" {
 	project_id = neon_project.test.id
 	name = "name_branch"
-	endpoints = [
-		{
+	endpoints = {
+		primary = {
 			type = "read_write"
 			autoscaling_limit_min_cu = 1
 			autoscaling_limit_max_cu = 1
 		}
-	]
+	}
 }
 
 resource "neon_database" "test" {
@@ -100,3 +114,69 @@ resource "neon_database" "test" {
 }
 `
 }
+
+func testDatabaseOwnerResource() string {
+	return `
+resource "neon_project" "test" {
+	name = "name_project"
+}
+
+resource "neon_branch" "test" {
+	project_id = neon_project.test.id
+	name = "name_branch"
+	endpoints = {
+		primary = {
+			type = "read_write"
+			autoscaling_limit_min_cu = 1
+			autoscaling_limit_max_cu = 1
+		}
+	}
+}
+
+resource "neon_role" "test" {
+	project_id = neon_project.test.id
+	branch_id = neon_branch.test.id
+	name = "name_owner"
+}
+
+resource "neon_database" "test" {
+	project_id = neon_project.test.id
+	branch_id = neon_branch.test.id
+	name = "name_database_updated"
+	owner_name = neon_role.test.name
+}
+`
+}
+
+func TestDatabaseInUse(t *testing.T) {
+	recorded, err := os.ReadFile(filepath.Join("testdata", "api_errors", "database_in_use.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range []struct {
+		name   string
+		status int
+		body   string
+		want   bool
+	}{
+		{"recorded error", http.StatusInternalServerError, string(recorded), true},
+		{"recorded error as a conflict", http.StatusConflict, string(recorded), true},
+		{"conflict on active connections", http.StatusConflict, `{"code":"","message":"the database has active connections"}`, true},
+		{"other conflict", http.StatusConflict, `{"code":"","message":"database with that name already exists"}`, false},
+		{"active connections without a conflict", http.StatusBadRequest, `{"code":"","message":"cannot count active connections"}`, false},
+		{"no api error", http.StatusConflict, `conflict`, false},
+	} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(v.status)
+			fmt.Fprint(w, v.body)
+		}))
+		response, err := resty.New().SetBaseURL(server.URL).R().Delete("/")
+		server.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := databaseInUse(response); got != v.want {
+			t.Errorf("databaseInUse(%s) = %t, want %t", v.name, got, v.want)
+		}
+	}
+}
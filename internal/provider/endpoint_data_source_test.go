@@ -1,44 +1,183 @@
 package provider
 
 import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestEndpointResource(t *testing.T) {
+	var id string
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
-				//Config: testEndpointResourceCreate(),
+				Config: testEndpointResourceCreate(),
 				Check: resource.ComposeAggregateTestCheckFunc(
-				//resource.TestCheckResourceAttr("data.neon_endpoint.test", "region_id", "aws-us-east-2"),
+					resource.TestCheckResourceAttr("data.neon_endpoint.test", "region_id", "aws-us-east-2"),
+					resource.TestCheckResourceAttrPair("data.neon_endpoint.test", "host", "neon_endpoint.test", "host"),
+					resource.TestMatchResourceAttr("neon_endpoint.test", "pooler_host", regexp.MustCompile(`^ep-[a-z0-9-]+-pooler\.`)),
+					testAccCheckResourceID("neon_endpoint.test", &id),
 				),
 			},
+			// The pooler can be switched off in place
+			{
+				Config: testEndpointResourcePooler(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_endpoint.test", "pooler_enabled", "false"),
+					testAccCheckResourceReplaced("neon_endpoint.test", &id, false),
+				),
+			},
+			// Changing the endpoint type replaces it
+			{
+				Config: testEndpointResourceReplace(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_endpoint.test", "type", "read_only"),
+					testAccCheckResourceReplaced("neon_endpoint.test", &id, true),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "neon_endpoint.test",
+				ImportState:       true,
+				ImportStateVerify: false,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					if rs, ok := s.RootModule().Resources["neon_endpoint.test"]; ok {
+						return fmt.Sprintf("%s/%s", rs.Primary.Attributes["project_id"], rs.Primary.ID), nil
+					}
+					return "", fmt.Errorf("cannot find neon_endpoint.test")
+				},
+			},
 		},
 	})
 }
 
 //(neon_project.test): https://github.com/hashicorp/terraform/issues/31527
-//cannot create multiple read_write endpoints in a project (for now)
+//cannot create multiple read_write endpoints in a branch, the endpoint is created on its own branch
 func testEndpointResourceCreate() string {
 	return `
 resource "neon_project" "test" {
 	name = "name_project"
 }
 
+resource "neon_branch" "test" {
+	project_id = neon_project.test.id
+	name = "name_branch"
+}
+
+resource "neon_endpoint" "test" {
+	project_id = neon_project.test.id
+	branch_id = neon_branch.test.id
+	type = "read_write"
+	region_id = "aws-us-east-2"
+}
+
+data "neon_endpoint" "test" {
+	project_id = neon_project.test.id
+	id = neon_endpoint.test.id
+}
+`
+}
+
+func testEndpointResourcePooler() string {
+	return `
+resource "neon_project" "test" {
+	name = "name_project"
+}
+
+resource "neon_branch" "test" {
+	project_id = neon_project.test.id
+	name = "name_branch"
+}
+
 resource "neon_endpoint" "test" {
 	project_id = neon_project.test.id
-	branch_id = (neon_project.test).branch.id
+	branch_id = neon_branch.test.id
 	type = "read_write"
 	region_id = "aws-us-east-2"
+	pooler_enabled = false
+}
+
+data "neon_endpoint" "test" {
+	project_id = neon_project.test.id
+	id = neon_endpoint.test.id
+}
+`
+}
+
+// the read_write endpoint of the branch is created with it, so the endpoint can
+// become read_only
+func testEndpointResourceReplace() string {
+	return `
+resource "neon_project" "test" {
+	name = "name_project"
+}
+
+resource "neon_branch" "test" {
+	project_id = neon_project.test.id
+	name = "name_branch"
+	endpoints = {
+		primary = {
+			type = "read_write"
+			autoscaling_limit_min_cu = 1
+			autoscaling_limit_max_cu = 1
+		}
+	}
+}
+
+resource "neon_endpoint" "test" {
+	project_id = neon_project.test.id
+	branch_id = neon_branch.test.id
+	type = "read_only"
+	region_id = "aws-us-east-2"
 }
 
 data "neon_endpoint" "test" {
-    project_id = neon_project.test.id
+	project_id = neon_project.test.id
 	id = neon_endpoint.test.id
 }
 `
 }
+
+func TestUpdateEndpointPoolerEnabled(t *testing.T) {
+	for _, tc := range []struct {
+		value types.Bool
+		want  string
+	}{
+		{types.BoolValue(false), `"pooler_enabled":false`},
+		{types.BoolValue(true), `"pooler_enabled":true`},
+		{types.BoolUnknown(), ""},
+		{types.BoolNull(), ""},
+	} {
+		body, err := json.Marshal(updateEndpoint{Pooler_enabled: configuredBool(tc.value)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		sent := strings.Contains(string(body), "pooler_enabled")
+		if tc.want == "" && sent || tc.want != "" && !strings.Contains(string(body), tc.want) {
+			t.Errorf("updateEndpoint with pooler_enabled %s = %s, want %q", tc.value, body, tc.want)
+		}
+	}
+}
+
+// TestUpdateEndpointSendsFalse checks switching disabled and passwordless_access
+// off is sent, false being the zero value.
+func TestUpdateEndpointSendsFalse(t *testing.T) {
+	body, err := json.Marshal(updateEndpoint{
+		Disabled:            configuredBool(types.BoolValue(false)),
+		Passwordless_access: configuredBool(types.BoolValue(false)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(body), `"disabled":false`) || !strings.Contains(string(body), `"passwordless_access":false`) {
+		t.Errorf("updateEndpoint = %s, want disabled and passwordless_access sent as false", body)
+	}
+}
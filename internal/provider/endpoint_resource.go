@@ -7,18 +7,28 @@ import (
 
 	"github.com/go-resty/resty/v2"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var _ resource.Resource = endpointResource{}
+var _ resource.ResourceWithUpgradeState = endpointResource{}
 var _ resource.ResourceWithImportState = endpointResource{}
+var _ resource.ResourceWithIdentity = endpointResource{}
+var _ resource.ResourceWithModifyPlan = endpointResource{}
+
+// endpointPoolerModes are the connection pooler modes supported by Neon, its
+// pgbouncer only runs in transaction mode.
+var endpointPoolerModes = []string{"transaction"}
 
 type endpointResource struct {
-	client *resty.Client
+	client  *resty.Client
+	regions *regionCache
 }
 
 type endpointResourceJSON struct {
@@ -43,6 +53,7 @@ type endpointResourceJSON struct {
 }
 type endpointResourceModel struct {
 	Host                  types.String `tfsdk:"host"`
+	PoolerHost            types.String `tfsdk:"pooler_host"`
 	Id                    types.String `tfsdk:"id"`
 	ProjectID             types.String `tfsdk:"project_id"`
 	BranchID              types.String `tfsdk:"branch_id"`
@@ -86,6 +97,7 @@ func (m *endpointResourceModel) ToEndpointResourceJSON() *endpointResourceJSON {
 func (m *endpointResourceJSON) ToEndpointResourceModel() *endpointResourceModel {
 	return &endpointResourceModel{
 		Host:                  types.StringValue(m.Host),
+		PoolerHost:            types.StringValue(poolerHost(m.Host)),
 		Id:                    types.StringValue(m.Id),
 		ProjectID:             types.StringValue(m.ProjectID),
 		BranchID:              types.StringValue(m.BranchID),
@@ -110,9 +122,29 @@ func (r endpointResource) Metadata(ctx context.Context, req resource.MetadataReq
 }
 
 func (r endpointResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := endpointResourceAttr()
+	for _, k := range []string{"project_id", "type", "region_id"} {
+		addStringPlanModifiers(attributes, k, stringplanmodifier.RequiresReplace())
+	}
+	for _, k := range []string{"id", "host", "pooler_host", "region_id", "pooler_mode", "created_at"} {
+		addStringPlanModifiers(attributes, k, stringplanmodifier.UseStateForUnknown())
+	}
+	for _, k := range []string{"autoscaling_limit_min_cu", "autoscaling_limit_max_cu"} {
+		addInt64PlanModifiers(attributes, k, int64planmodifier.UseStateForUnknown())
+	}
+	for _, k := range []string{"pooler_enabled", "disabled", "passwordless_access"} {
+		addBoolPlanModifiers(attributes, k, boolplanmodifier.UseStateForUnknown())
+	}
 	resp.Schema = schema.Schema{
+		Version:             1,
 		MarkdownDescription: "Neon endpoint resource",
-		Attributes:          endpointResourceAttr(),
+		Attributes:          attributes,
+	}
+}
+
+func (r endpointResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: upgradeFromV0(r),
 	}
 }
 
@@ -122,6 +154,10 @@ func endpointResourceAttr() map[string]schema.Attribute {
 			MarkdownDescription: "neon host",
 			Computed:            true,
 		},
+		"pooler_host": schema.StringAttribute{
+			MarkdownDescription: "connection pooler host, for clients connecting through the pooler",
+			Computed:            true,
+		},
 		"id": schema.StringAttribute{
 			MarkdownDescription: "endpoint id",
 			Computed:            true,
@@ -173,7 +209,7 @@ func endpointResourceAttr() map[string]schema.Attribute {
 			MarkdownDescription: "pooler mode",
 			Computed:            true,
 			Optional:            true,
-			Validators:          []validator.String{stringvalidator.OneOf("transaction")},
+			Validators:          []validator.String{stringvalidator.OneOf(endpointPoolerModes...)},
 		},
 		"disabled": schema.BoolAttribute{
 			MarkdownDescription: "disabled",
@@ -207,12 +243,21 @@ type createEndpoint struct {
 	Settings                 *endpointSettingsJSON `json:"settings,omitempty"`
 	Autoscaling_limit_min_cu int64                 `json:"autoscaling_limit_min_cu,omitempty"`
 	Autoscaling_limit_max_cu int64                 `json:"autoscaling_limit_max_cu,omitempty"`
-	Pooler_enabled           bool                  `json:"pooler_enabled,omitempty"`
+	Pooler_enabled           *bool                 `json:"pooler_enabled,omitempty"`
 	Pooler_mode              string                `json:"pooler_mode,omitempty"`
 	Disabled                 bool                  `json:"disabled,omitempty"`
 	Passwordless_access      bool                  `json:"passwordless_access,omitempty"`
 }
 
+// configuredBool returns the value planned for an optional attribute, nil
+// when it is left to the api so false is still sent when set.
+func configuredBool(v types.Bool) *bool {
+	if v.IsUnknown() {
+		return nil
+	}
+	return v.ValueBoolPointer()
+}
+
 func (r endpointResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data endpointResourceModel
 
@@ -231,7 +276,7 @@ func (r endpointResource) Create(ctx context.Context, req resource.CreateRequest
 			Type:                     data.Type.ValueString(),
 			Autoscaling_limit_min_cu: data.AutoscalingLimitMinCu.ValueInt64(),
 			Autoscaling_limit_max_cu: data.AutoscalingLimitMaxCu.ValueInt64(),
-			Pooler_enabled:           data.PoolerEnabled.ValueBool(),
+			Pooler_enabled:           configuredBool(data.PoolerEnabled),
 			Pooler_mode:              data.PoolerMode.ValueString(),
 			Disabled:                 data.Disabled.ValueBool(),
 			Passwordless_access:      data.PasswordlessAccess.ValueBool(),
@@ -255,6 +300,7 @@ func (r endpointResource) Create(ctx context.Context, req resource.CreateRequest
 	data = *endpoint.Endpoint.ToEndpointResourceModel()
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.State, resp.Identity)...)
 }
 
 func (r endpointResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -282,6 +328,7 @@ func (r endpointResource) Read(ctx context.Context, req resource.ReadRequest, re
 	}
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.State, resp.Identity)...)
 }
 
 type updateEndpoint struct {
@@ -289,10 +336,10 @@ type updateEndpoint struct {
 	Settings                 *endpointSettingsJSON `json:"settings,omitempty"`
 	Autoscaling_limit_min_cu int64                 `json:"autoscaling_limit_min_cu,omitempty"`
 	Autoscaling_limit_max_cu int64                 `json:"autoscaling_limit_max_cu,omitempty"`
-	Pooler_enabled           bool                  `json:"pooler_enabled,omitempty"`
+	Pooler_enabled           *bool                 `json:"pooler_enabled,omitempty"`
 	Pooler_mode              string                `json:"pooler_mode,omitempty"`
-	Disabled                 bool                  `json:"disabled,omitempty"`
-	Passwordless_access      bool                  `json:"passwordless_access,omitempty"`
+	Disabled                 *bool                 `json:"disabled,omitempty"`
+	Passwordless_access      *bool                 `json:"passwordless_access,omitempty"`
 }
 
 func (r endpointResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -304,18 +351,22 @@ func (r endpointResource) Update(ctx context.Context, req resource.UpdateRequest
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	content := updateEndpoint{
-		Branch_id:                data.BranchID.ValueString(),
-		Autoscaling_limit_min_cu: data.AutoscalingLimitMinCu.ValueInt64(),
-		Autoscaling_limit_max_cu: data.AutoscalingLimitMaxCu.ValueInt64(),
-		Pooler_enabled:           data.PoolerEnabled.ValueBool(),
-		Pooler_mode:              data.PoolerMode.ValueString(),
-		Disabled:                 data.Disabled.ValueBool(),
-		Passwordless_access:      data.PasswordlessAccess.ValueBool(),
+	content := struct {
+		Endpoint updateEndpoint `json:"endpoint"`
+	}{
+		Endpoint: updateEndpoint{
+			Branch_id:                data.BranchID.ValueString(),
+			Autoscaling_limit_min_cu: data.AutoscalingLimitMinCu.ValueInt64(),
+			Autoscaling_limit_max_cu: data.AutoscalingLimitMaxCu.ValueInt64(),
+			Pooler_enabled:           configuredBool(data.PoolerEnabled),
+			Pooler_mode:              data.PoolerMode.ValueString(),
+			Disabled:                 configuredBool(data.Disabled),
+			Passwordless_access:      configuredBool(data.PasswordlessAccess),
+		},
 	}
 	response, _ := r.client.R().
 		SetBody(content).
-		Patch(fmt.Sprintf("/projects/%s/endpoints", data.ProjectID.ValueString()))
+		Patch(fmt.Sprintf("/projects/%s/endpoints/%s", data.ProjectID.ValueString(), data.Id.ValueString()))
 	if response.IsError() {
 		resp.Diagnostics.AddError(fmt.Sprintf("Failed to update endpoint resource with a status code: %s", response.Status()), "")
 		return
@@ -332,6 +383,7 @@ func (r endpointResource) Update(ctx context.Context, req resource.UpdateRequest
 	}
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.State, resp.Identity)...)
 }
 
 func (r endpointResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -348,6 +400,14 @@ func (r endpointResource) Delete(ctx context.Context, req resource.DeleteRequest
 	resp.State.RemoveResource(ctx)
 }
 
+func (r endpointResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(r.regions.validatePlan(ctx, req.Plan)...)
+}
+
+func (r endpointResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identitySchema("project_id", "id")
+}
+
 func (r endpointResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importState(ctx, req, resp, "endpoint", "project_id/id")
 }
@@ -0,0 +1,253 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jackc/pgx/v5"
+)
+
+type extensionResource struct {
+	client *resty.Client
+}
+type extensionResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	ProjectID    types.String `tfsdk:"project_id"`
+	BranchID     types.String `tfsdk:"branch_id"`
+	DatabaseName types.String `tfsdk:"database_name"`
+	Name         types.String `tfsdk:"name"`
+	Version      types.String `tfsdk:"version"`
+	Schema       types.String `tfsdk:"schema"`
+}
+
+var _ resource.Resource = extensionResource{}
+var _ resource.ResourceWithUpgradeState = extensionResource{}
+var _ resource.ResourceWithImportState = extensionResource{}
+var _ resource.ResourceWithIdentity = extensionResource{}
+
+func createExtensionStatement(name, schemaName, version string) string {
+	statement := "CREATE EXTENSION " + pgx.Identifier{name}.Sanitize()
+	if schemaName != "" {
+		statement += " WITH SCHEMA " + pgx.Identifier{schemaName}.Sanitize()
+	}
+	if version != "" {
+		statement += " VERSION " + pgx.Identifier{version}.Sanitize()
+	}
+	return statement
+}
+
+// readExtension returns the installed version and schema of an extension, or
+// empty strings when it is not installed.
+func readExtension(ctx context.Context, conn *pgx.Conn, name string) (string, string, error) {
+	var version, schemaName string
+	err := conn.QueryRow(ctx, `SELECT e.extversion, n.nspname
+FROM pg_extension e JOIN pg_namespace n ON n.oid = e.extnamespace
+WHERE e.extname = $1`, name).Scan(&version, &schemaName)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", "", nil
+	}
+	return version, schemaName, err
+}
+
+// toState fills the computed attributes from the installed extension.
+func (m *extensionResourceModel) toState(ctx context.Context, conn *pgx.Conn) (bool, error) {
+	version, schemaName, err := readExtension(ctx, conn, m.Name.ValueString())
+	if err != nil || version == "" {
+		return false, err
+	}
+	m.ID = types.StringValue(fmt.Sprintf("%s/%s/%s/%s", m.ProjectID.ValueString(), m.BranchID.ValueString(), m.DatabaseName.ValueString(), m.Name.ValueString()))
+	m.Version = types.StringValue(version)
+	m.Schema = types.StringValue(schemaName)
+	return true, nil
+}
+
+func (r extensionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_extension"
+}
+
+func (r extensionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version:             1,
+		MarkdownDescription: "Postgres extension installed in a database, managed by connecting to the branch read_write endpoint as the database owner",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "extension identifier",
+				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "project id",
+				Required:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"branch_id": schema.StringAttribute{
+				MarkdownDescription: "branch id",
+				Required:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"database_name": schema.StringAttribute{
+				MarkdownDescription: "database name",
+				Required:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "extension name, e.g. vector",
+				Required:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "extension version, defaults to the default version of the extension",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"schema": schema.StringAttribute{
+				MarkdownDescription: "schema the extension objects are created in",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+		},
+	}
+}
+
+func (r extensionResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: upgradeFromV0(r),
+	}
+}
+
+func (r extensionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data extensionResourceModel
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	conn, err := openDatabase(ctx, r.client, data.ProjectID.ValueString(), data.BranchID.ValueString(), data.DatabaseName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to connect to database", err.Error())
+		return
+	}
+	defer conn.Close(ctx)
+
+	_, err = conn.Exec(ctx, createExtensionStatement(data.Name.ValueString(), data.Schema.ValueString(), data.Version.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create extension", err.Error())
+		return
+	}
+	found, err := data.toState(ctx, conn)
+	if err != nil || !found {
+		resp.Diagnostics.AddError("Failed to read extension", fmt.Sprintf("extension %s not found after creation: %v", data.Name.ValueString(), err))
+		return
+	}
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.State, resp.Identity)...)
+}
+
+func (r extensionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data extensionResourceModel
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	conn, err := openDatabase(ctx, r.client, data.ProjectID.ValueString(), data.BranchID.ValueString(), data.DatabaseName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to connect to database", err.Error())
+		return
+	}
+	defer conn.Close(ctx)
+
+	found, err := data.toState(ctx, conn)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read extension", err.Error())
+		return
+	}
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.State, resp.Identity)...)
+}
+
+func (r extensionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state extensionResourceModel
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	conn, err := openDatabase(ctx, r.client, data.ProjectID.ValueString(), data.BranchID.ValueString(), data.DatabaseName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to connect to database", err.Error())
+		return
+	}
+	defer conn.Close(ctx)
+
+	name := pgx.Identifier{data.Name.ValueString()}.Sanitize()
+	if !data.Version.IsUnknown() && !data.Version.Equal(state.Version) {
+		_, err = conn.Exec(ctx, fmt.Sprintf("ALTER EXTENSION %s UPDATE TO %s", name, pgx.Identifier{data.Version.ValueString()}.Sanitize()))
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to update extension version", err.Error())
+			return
+		}
+	}
+	if !data.Schema.IsUnknown() && !data.Schema.Equal(state.Schema) {
+		_, err = conn.Exec(ctx, fmt.Sprintf("ALTER EXTENSION %s SET SCHEMA %s", name, pgx.Identifier{data.Schema.ValueString()}.Sanitize()))
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to update extension schema", err.Error())
+			return
+		}
+	}
+	found, err := data.toState(ctx, conn)
+	if err != nil || !found {
+		resp.Diagnostics.AddError("Failed to read extension", fmt.Sprintf("extension %s not found after update: %v", data.Name.ValueString(), err))
+		return
+	}
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.State, resp.Identity)...)
+}
+
+func (r extensionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data extensionResourceModel
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	conn, err := openDatabase(ctx, r.client, data.ProjectID.ValueString(), data.BranchID.ValueString(), data.DatabaseName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to connect to database", err.Error())
+		return
+	}
+	defer conn.Close(ctx)
+
+	_, err = conn.Exec(ctx, "DROP EXTENSION IF EXISTS "+pgx.Identifier{data.Name.ValueString()}.Sanitize())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to delete extension", err.Error())
+		return
+	}
+	resp.State.RemoveResource(ctx)
+}
+
+func (r extensionResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identitySchema("project_id", "branch_id", "database_name", "name")
+}
+
+func (r extensionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importState(ctx, req, resp, "extension", "project_id/branch_id/database_name/name")
+}
@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestCreateExtensionStatement(t *testing.T) {
+	tests := []struct {
+		name, schema, version string
+		want                  string
+	}{
+		{name: "vector", want: `CREATE EXTENSION "vector"`},
+		{name: "postgis", schema: "gis", want: `CREATE EXTENSION "postgis" WITH SCHEMA "gis"`},
+		{name: "pg_stat_statements", schema: "public", version: "1.10", want: `CREATE EXTENSION "pg_stat_statements" WITH SCHEMA "public" VERSION "1.10"`},
+	}
+	for _, tt := range tests {
+		if got := createExtensionStatement(tt.name, tt.schema, tt.version); got != tt.want {
+			t.Errorf("createExtensionStatement() = %s, want %s", got, tt.want)
+		}
+	}
+}
+
+func TestExtensionResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckPostgres(t,
+				"DROP EXTENSION IF EXISTS pg_trgm",
+				"CREATE SCHEMA IF NOT EXISTS tf_extension",
+			)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testExtensionResource("public"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_extension.test", "id", "project/branch/database/pg_trgm"),
+					resource.TestCheckResourceAttr("neon_extension.test", "schema", "public"),
+					resource.TestCheckResourceAttrSet("neon_extension.test", "version"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "neon_extension.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testExtensionResource("tf_extension"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_extension.test", "schema", "tf_extension"),
+				),
+			},
+		},
+	})
+}
+
+func testExtensionResource(schema string) string {
+	return `
+resource "neon_extension" "test" {
+	project_id = "project"
+	branch_id = "branch"
+	database_name = "database"
+	name = "pg_trgm"
+	schema = "` + schema + `"
+}
+`
+}
@@ -0,0 +1,276 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+)
+
+var hclLabelRegex = regexp.MustCompile(`[^a-z0-9_]+`)
+
+type generatedBranch struct {
+	branchResourceJSON
+	Default bool `json:"default"`
+	Primary bool `json:"primary"`
+
+	endpoints []endpointResourceJSON
+	roles     []roleResourceJSON
+	databases []databaseResourceJSON
+	ref       string
+}
+
+type hclAttribute struct {
+	name  string
+	value string
+}
+
+// GenerateConfig walks the projects visible to client, restricted to orgID
+// when set, and returns a configuration file per project with the resources
+// and import blocks adopting its branches, endpoints, roles and databases.
+//
+// The default branch, its first read_write endpoint, its first database and
+// the database owner are created with the project and are not generated on
+// their own. Roles and databases inherited from the parent branch are skipped.
+func GenerateConfig(client *resty.Client, orgID string) (map[string][]byte, error) {
+	projects, err := listProjects(client, "", orgID)
+	if err != nil {
+		return nil, err
+	}
+	files := map[string][]byte{}
+	labels := map[string]int{}
+	for _, v := range projects {
+		label := uniqueHCLLabel(v.Name, labels)
+		content, err := generateProjectConfig(client, v, orgID, label)
+		if err != nil {
+			return nil, err
+		}
+		files[label+".tf"] = content
+	}
+	return files, nil
+}
+
+func generateProjectConfig(client *resty.Client, project projectDataJSON, orgID, label string) ([]byte, error) {
+	branches, err := listGeneratedBranches(client, project.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &strings.Builder{}
+	attributes := []hclAttribute{
+		{"name", hclString(project.Name)},
+		{"region_id", hclString(project.RegionID)},
+		{"pg_version", strconv.FormatInt(project.PgVersion, 10)},
+	}
+	if orgID != "" {
+		attributes = append(attributes, hclAttribute{"org_id", hclString(orgID)})
+	}
+	projectAddress := "neon_project." + label
+	writeHCLResource(b, "neon_project", label, attributes, project.ID)
+
+	labels := map[string]int{}
+	byID := map[string]*generatedBranch{}
+	for i := range branches {
+		branch := &branches[i]
+		byID[branch.ID] = branch
+		if branch.Default || branch.Primary {
+			branch.ref = projectAddress + ".branch.id"
+			continue
+		}
+		branch.ref = "neon_branch." + uniqueHCLLabel(branch.Name, labels) + ".id"
+	}
+
+	for i := range branches {
+		branch := &branches[i]
+		if branch.Default || branch.Primary {
+			continue
+		}
+		// endpoints are labelled with their ids, as an import labels them, so
+		// the imported branch plans without changes
+		endpoints := []string{}
+		for _, v := range branch.endpoints {
+			endpoints = append(endpoints, fmt.Sprintf("\t\t%s = {\n\t\t\ttype = %s\n\t\t\tautoscaling_limit_min_cu = %d\n\t\t\tautoscaling_limit_max_cu = %d\n\t\t}\n",
+				hclString(v.Id), hclString(v.Type), v.AutoscalingLimitMinCu, v.AutoscalingLimitMaxCu))
+		}
+		attributes := []hclAttribute{
+			{"project_id", projectAddress + ".id"},
+			{"name", hclString(branch.Name)},
+		}
+		if len(endpoints) > 0 {
+			attributes = append(attributes, hclAttribute{"endpoints", "{\n" + strings.Join(endpoints, "") + "\t}"})
+		}
+		writeHCLResource(b, "neon_branch", resourceLabel(branch.ref), attributes, fmt.Sprintf("%s/%s", project.ID, branch.ID))
+	}
+
+	for i := range branches {
+		branch := &branches[i]
+		if !branch.Default && !branch.Primary {
+			continue
+		}
+		created := false
+		for _, v := range branch.endpoints {
+			if v.Type == "read_write" && !created {
+				created = true
+				continue
+			}
+			writeHCLResource(b, "neon_endpoint", uniqueHCLLabel(branch.Name+"_"+v.Type, labels), []hclAttribute{
+				{"project_id", projectAddress + ".id"},
+				{"branch_id", branch.ref},
+				{"type", hclString(v.Type)},
+				{"autoscaling_limit_min_cu", strconv.FormatInt(v.AutoscalingLimitMinCu, 10)},
+				{"autoscaling_limit_max_cu", strconv.FormatInt(v.AutoscalingLimitMaxCu, 10)},
+			}, fmt.Sprintf("%s/%s", project.ID, v.Id))
+		}
+	}
+
+	for i := range branches {
+		branch := &branches[i]
+		parent := byID[branch.ParentID]
+		skipRoles := map[string]bool{}
+		skipDatabases := map[string]bool{}
+		if parent != nil && parent != branch {
+			for _, v := range parent.roles {
+				skipRoles[v.Name] = true
+			}
+			for _, v := range parent.databases {
+				skipDatabases[v.Name] = true
+			}
+		}
+		if (branch.Default || branch.Primary) && len(branch.databases) > 0 {
+			skipDatabases[branch.databases[0].Name] = true
+			skipRoles[branch.databases[0].OwnerName] = true
+		}
+
+		roleRefs := map[string]string{}
+		for _, v := range branch.roles {
+			if v.Protected || skipRoles[v.Name] {
+				continue
+			}
+			roleLabel := uniqueHCLLabel(branch.Name+"_"+v.Name, labels)
+			roleRefs[v.Name] = "neon_role." + roleLabel + ".name"
+			writeHCLResource(b, "neon_role", roleLabel, []hclAttribute{
+				{"project_id", projectAddress + ".id"},
+				{"branch_id", branch.ref},
+				{"name", hclString(v.Name)},
+			}, fmt.Sprintf("%s/%s/%s", project.ID, branch.ID, v.Name))
+		}
+		for _, v := range branch.databases {
+			if skipDatabases[v.Name] {
+				continue
+			}
+			owner, ok := roleRefs[v.OwnerName]
+			if !ok {
+				owner = hclString(v.OwnerName)
+			}
+			writeHCLResource(b, "neon_database", uniqueHCLLabel(branch.Name+"_"+v.Name, labels), []hclAttribute{
+				{"project_id", projectAddress + ".id"},
+				{"branch_id", branch.ref},
+				{"name", hclString(v.Name)},
+				{"owner_name", owner},
+			}, fmt.Sprintf("%s/%s/%s", project.ID, branch.ID, v.Name))
+		}
+	}
+	return []byte(b.String()), nil
+}
+
+// listGeneratedBranches returns the project branches with their endpoints,
+// roles and databases, sorted by creation so parents come first.
+func listGeneratedBranches(client *resty.Client, projectID string) ([]generatedBranch, error) {
+	branches := struct {
+		Branches []generatedBranch `json:"branches"`
+	}{}
+	err := getGeneratedList(client, fmt.Sprintf("/projects/%s/branches", projectID), "project branches", &branches)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(branches.Branches, func(i, j int) bool {
+		return branches.Branches[i].CreatedAt < branches.Branches[j].CreatedAt
+	})
+	for i := range branches.Branches {
+		branch := &branches.Branches[i]
+		branchURL := fmt.Sprintf("/projects/%s/branches/%s", projectID, branch.ID)
+
+		endpoints := struct {
+			Endpoints []endpointResourceJSON `json:"endpoints"`
+		}{}
+		err = getGeneratedList(client, branchURL+"/endpoints", "branch endpoints", &endpoints)
+		if err != nil {
+			return nil, err
+		}
+		branch.endpoints = endpoints.Endpoints
+
+		roles := struct {
+			Roles []roleResourceJSON `json:"roles"`
+		}{}
+		err = getGeneratedList(client, branchURL+"/roles", "branch roles", &roles)
+		if err != nil {
+			return nil, err
+		}
+		branch.roles = roles.Roles
+
+		databases := struct {
+			Databases []databaseResourceJSON `json:"databases"`
+		}{}
+		err = getGeneratedList(client, branchURL+"/databases", "branch databases", &databases)
+		if err != nil {
+			return nil, err
+		}
+		branch.databases = databases.Databases
+	}
+	return branches.Branches, nil
+}
+
+func getGeneratedList(client *resty.Client, url, what string, out interface{}) error {
+	response, err := client.R().Get(url)
+	if err != nil {
+		return err
+	}
+	if response.IsError() {
+		return fmt.Errorf("failed to list %s with a status code: %s", what, response.Status())
+	}
+	return json.Unmarshal(response.Body(), out)
+}
+
+// writeHCLResource writes a resource block followed by the import block
+// adopting it with id, in the format expected by the resource ImportState.
+func writeHCLResource(b *strings.Builder, resourceType, label string, attributes []hclAttribute, id string) {
+	width := 0
+	for _, v := range attributes {
+		if len(v.name) > width {
+			width = len(v.name)
+		}
+	}
+	fmt.Fprintf(b, "resource %q %q {\n", resourceType, label)
+	for _, v := range attributes {
+		fmt.Fprintf(b, "\t%-*s = %s\n", width, v.name, v.value)
+	}
+	fmt.Fprintf(b, "}\n\nimport {\n\tto = %s.%s\n\tid = %s\n}\n\n", resourceType, label, hclString(id))
+}
+
+// resourceLabel returns the label of a reference like neon_branch.label.id.
+func resourceLabel(ref string) string {
+	return strings.Split(ref, ".")[1]
+}
+
+// uniqueHCLLabel turns name into a valid resource label, suffixed with a
+// counter when it was already used.
+func uniqueHCLLabel(name string, used map[string]int) string {
+	label := strings.Trim(hclLabelRegex.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if label == "" || (label[0] >= '0' && label[0] <= '9') {
+		label = "r_" + label
+	}
+	used[label]++
+	if used[label] > 1 {
+		return fmt.Sprintf("%s_%d", label, used[label])
+	}
+	return label
+}
+
+func hclString(s string) string {
+	s = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`, "${", "$${", "%{", "%%{").Replace(s)
+	return `"` + s + `"`
+}
@@ -0,0 +1,237 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// newGenerateTestServer serves a project with a default branch and a dev
+// branch branched from it.
+func newGenerateTestServer() *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/projects", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"projects":[{"id":"p-1","name":"My App","region_id":"aws-us-east-2","pg_version":16}]}`)
+	})
+	mux.HandleFunc("/projects/p-1/branches", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"branches":[{"id":"br-dev","project_id":"p-1","parent_id":"br-main","name":"dev","created_at":"2024-02-01T00:00:00Z"},
+			{"id":"br-main","project_id":"p-1","name":"main","default":true,"created_at":"2024-01-01T00:00:00Z"}]}`)
+	})
+	mux.HandleFunc("/projects/p-1/branches/br-main/endpoints", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"endpoints":[{"id":"ep-rw","type":"read_write"},{"id":"ep-ro","type":"read_only","autoscaling_limit_min_cu":1,"autoscaling_limit_max_cu":2}]}`)
+	})
+	mux.HandleFunc("/projects/p-1/branches/br-main/roles", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"roles":[{"name":"owner"},{"name":"app"},{"name":"neon_superuser","protected":true}]}`)
+	})
+	mux.HandleFunc("/projects/p-1/branches/br-main/databases", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"databases":[{"name":"neondb","owner_name":"owner"},{"name":"appdb","owner_name":"app"}]}`)
+	})
+	mux.HandleFunc("/projects/p-1/branches/br-dev/endpoints", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"endpoints":[{"id":"ep-dev","type":"read_write","autoscaling_limit_min_cu":1,"autoscaling_limit_max_cu":1}]}`)
+	})
+	mux.HandleFunc("/projects/p-1/branches/br-dev/roles", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"roles":[{"name":"owner"},{"name":"app"},{"name":"tester"}]}`)
+	})
+	mux.HandleFunc("/projects/p-1/branches/br-dev/databases", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"databases":[{"name":"neondb","owner_name":"owner"},{"name":"appdb","owner_name":"app"}]}`)
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestGenerateConfig(t *testing.T) {
+	server := newGenerateTestServer()
+	defer server.Close()
+
+	files, err := GenerateConfig(resty.New().SetBaseURL(server.URL), "")
+	if err != nil {
+		t.Fatalf("GenerateConfig() error = %s", err)
+	}
+	content, ok := files["my_app.tf"]
+	if !ok || len(files) != 1 {
+		t.Fatalf("GenerateConfig() files = %v, want my_app.tf", files)
+	}
+	config := string(content)
+
+	for _, want := range []string{
+		"import {\n\tto = neon_project.my_app\n\tid = \"p-1\"\n}",
+		"import {\n\tto = neon_branch.dev\n\tid = \"p-1/br-dev\"\n}",
+		"import {\n\tto = neon_endpoint.main_read_only\n\tid = \"p-1/ep-ro\"\n}",
+		"import {\n\tto = neon_role.main_app\n\tid = \"p-1/br-main/app\"\n}",
+		"import {\n\tto = neon_role.dev_tester\n\tid = \"p-1/br-dev/tester\"\n}",
+		"import {\n\tto = neon_database.main_appdb\n\tid = \"p-1/br-main/appdb\"\n}",
+		"\tbranch_id  = neon_project.my_app.branch.id\n",
+		"\towner_name = neon_role.main_app.name\n",
+		"\tpg_version = 16\n",
+		// labelled with the endpoint id as on import
+		"\t\t\"ep-dev\" = {\n\t\t\ttype = \"read_write\"\n",
+	} {
+		if !strings.Contains(config, want) {
+			t.Errorf("GenerateConfig() = %s\nwant it to contain %q", config, want)
+		}
+	}
+	for _, unwanted := range []string{`"ep-rw"`, `"p-1/ep-dev"`, "neon_role.main_owner", "neon_role.dev_app", "neon_database.dev_appdb", "neon_superuser"} {
+		if strings.Contains(config, unwanted) {
+			t.Errorf("GenerateConfig() = %s\nwant it not to contain %q", config, unwanted)
+		}
+	}
+}
+
+// TestGeneratedConfigMatchesSchemas parses the generated configuration and
+// checks every resource sets its required attributes and only configurable
+// attributes, with values passing their validators.
+func TestGeneratedConfigMatchesSchemas(t *testing.T) {
+	ctx := context.Background()
+	server := newGenerateTestServer()
+	defer server.Close()
+
+	schemas := map[string]schema.Schema{}
+	for _, factory := range New("test")().Resources(ctx) {
+		r := factory()
+		metadata := resource.MetadataResponse{}
+		r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "neon"}, &metadata)
+		resp := resource.SchemaResponse{}
+		r.Schema(ctx, resource.SchemaRequest{}, &resp)
+		schemas[metadata.TypeName] = resp.Schema
+	}
+
+	files, err := GenerateConfig(resty.New().SetBaseURL(server.URL), "")
+	if err != nil {
+		t.Fatalf("GenerateConfig() error = %s", err)
+	}
+	for name, content := range files {
+		file, diags := hclsyntax.ParseConfig(content, name, hcl.InitialPos)
+		if diags.HasErrors() {
+			t.Fatalf("%s: %s", name, diags)
+		}
+		for _, block := range file.Body.(*hclsyntax.Body).Blocks {
+			if block.Type != "resource" {
+				continue
+			}
+			address := strings.Join(block.Labels, ".")
+			resourceSchema, ok := schemas[block.Labels[0]]
+			if !ok {
+				t.Errorf("%s is not a resource", address)
+				continue
+			}
+			for attributeName, attribute := range resourceSchema.Attributes {
+				if _, ok := block.Body.Attributes[attributeName]; !ok && attribute.IsRequired() {
+					t.Errorf("%s misses the required %s", address, attributeName)
+				}
+			}
+			for attributeName, v := range block.Body.Attributes {
+				attribute, ok := resourceSchema.Attributes[attributeName]
+				if !ok {
+					t.Errorf("%s.%s is not an attribute", address, attributeName)
+					continue
+				}
+				// references are only known once applied
+				if _, ok := v.Expr.(*hclsyntax.ScopeTraversalExpr); ok {
+					continue
+				}
+				value, diags := v.Expr.Value(nil)
+				if diags.HasErrors() {
+					t.Errorf("%s.%s: %s", address, attributeName, diags)
+					continue
+				}
+				checkGeneratedAttribute(ctx, t, address+"."+attributeName, attribute, value)
+			}
+		}
+	}
+}
+
+func checkGeneratedAttribute(ctx context.Context, t *testing.T, address string, attribute schema.Attribute, value cty.Value) {
+	if !attribute.IsRequired() && !attribute.IsOptional() {
+		t.Errorf("%s is not configurable", address)
+		return
+	}
+	var diags diag.Diagnostics
+	switch a := attribute.(type) {
+	case schema.StringAttribute:
+		if value.Type() != cty.String {
+			t.Errorf("%s = %#v, want a string", address, value)
+			return
+		}
+		for _, v := range a.Validators {
+			resp := validator.StringResponse{}
+			v.ValidateString(ctx, validator.StringRequest{Path: path.Root(address), ConfigValue: types.StringValue(value.AsString())}, &resp)
+			diags.Append(resp.Diagnostics...)
+		}
+	case schema.Int64Attribute:
+		if value.Type() != cty.Number {
+			t.Errorf("%s = %#v, want a number", address, value)
+			return
+		}
+		number, accuracy := value.AsBigFloat().Int64()
+		if accuracy != big.Exact {
+			t.Errorf("%s = %s, want a whole number", address, value.AsBigFloat())
+			return
+		}
+		for _, v := range a.Validators {
+			resp := validator.Int64Response{}
+			v.ValidateInt64(ctx, validator.Int64Request{Path: path.Root(address), ConfigValue: types.Int64Value(number)}, &resp)
+			diags.Append(resp.Diagnostics...)
+		}
+	case schema.MapNestedAttribute:
+		if !value.Type().IsObjectType() {
+			t.Errorf("%s = %#v, want a map", address, value)
+			return
+		}
+		for key, element := range value.AsValueMap() {
+			if !element.Type().IsObjectType() {
+				t.Errorf("%s[%q] = %#v, want an object", address, key, element)
+				continue
+			}
+			nested := element.AsValueMap()
+			for name, v := range a.NestedObject.Attributes {
+				if _, ok := nested[name]; !ok && v.IsRequired() {
+					t.Errorf("%s[%q] misses the required %s", address, key, name)
+				}
+			}
+			for name, v := range nested {
+				nestedAttribute, ok := a.NestedObject.Attributes[name]
+				if !ok {
+					t.Errorf("%s[%q].%s is not an attribute", address, key, name)
+					continue
+				}
+				checkGeneratedAttribute(ctx, t, fmt.Sprintf("%s[%q].%s", address, key, name), nestedAttribute, v)
+			}
+		}
+	default:
+		t.Errorf("%s has an unchecked type %T", address, attribute)
+	}
+	for _, v := range diags.Errors() {
+		t.Errorf("%s: %s: %s", address, v.Summary(), v.Detail())
+	}
+}
+
+func TestUniqueHCLLabel(t *testing.T) {
+	used := map[string]int{}
+	for _, v := range []struct{ name, want string }{
+		{"My App", "my_app"},
+		{"my-app", "my_app_2"},
+		{"1st", "r_1st"},
+		{"", "r_"},
+	} {
+		if got := uniqueHCLLabel(v.name, used); got != v.want {
+			t.Errorf("uniqueHCLLabel(%q) = %q, want %q", v.name, got, v.want)
+		}
+	}
+	if got := hclString(`a "b" ${c}`); got != `"a \"b\" $${c}"` {
+		t.Errorf("hclString() = %s", got)
+	}
+}
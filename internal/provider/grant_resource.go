@@ -0,0 +1,519 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jackc/pgx/v5"
+)
+
+type grantResource struct {
+	client *resty.Client
+}
+type grantResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	ProjectID    types.String `tfsdk:"project_id"`
+	BranchID     types.String `tfsdk:"branch_id"`
+	DatabaseName types.String `tfsdk:"database_name"`
+	Role         types.String `tfsdk:"role"`
+	Schema       types.String `tfsdk:"schema"`
+	ObjectType   types.String `tfsdk:"object_type"`
+	Objects      types.Set    `tfsdk:"objects"`
+	Privileges   types.Set    `tfsdk:"privileges"`
+}
+
+// grant is the sql side of a grantResourceModel.
+type grant struct {
+	Database   string
+	Role       string
+	Schema     string
+	ObjectType string
+	Objects    []string
+	Privileges []string
+}
+
+// grantPrivileges lists the privileges that can be granted on each object type.
+var grantPrivileges = map[string][]string{
+	"database": {"CREATE", "CONNECT", "TEMPORARY"},
+	"schema":   {"CREATE", "USAGE"},
+	"table":    {"SELECT", "INSERT", "UPDATE", "DELETE", "TRUNCATE", "REFERENCES", "TRIGGER"},
+	"sequence": {"USAGE", "SELECT", "UPDATE"},
+}
+
+// grantRelkinds maps table and sequence grants to the pg_class kinds they cover.
+var grantRelkinds = map[string][]string{
+	"table":    {"r", "p", "v", "m", "f"},
+	"sequence": {"S"},
+}
+
+var _ resource.Resource = grantResource{}
+var _ resource.ResourceWithUpgradeState = grantResource{}
+var _ resource.ResourceWithValidateConfig = grantResource{}
+var _ resource.ResourceWithImportState = grantResource{}
+var _ resource.ResourceWithIdentity = grantResource{}
+
+func (m *grantResourceModel) toGrant(ctx context.Context) (*grant, error) {
+	g := &grant{
+		Database:   m.DatabaseName.ValueString(),
+		Role:       m.Role.ValueString(),
+		Schema:     m.Schema.ValueString(),
+		ObjectType: m.ObjectType.ValueString(),
+	}
+	if !m.Objects.IsNull() {
+		if diags := m.Objects.ElementsAs(ctx, &g.Objects, false); diags.HasError() {
+			return nil, fmt.Errorf("cannot read objects")
+		}
+	}
+	if diags := m.Privileges.ElementsAs(ctx, &g.Privileges, false); diags.HasError() {
+		return nil, fmt.Errorf("cannot read privileges")
+	}
+	sort.Strings(g.Objects)
+	sort.Strings(g.Privileges)
+	if err := g.validate(); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// validate checks the privileges against grantPrivileges. Privileges unknown
+// when the configuration was validated reach the plan unchecked, and are
+// written as is in the GRANT statement.
+func (g *grant) validate() error {
+	if _, ok := grantPrivileges[g.ObjectType]; !ok {
+		return fmt.Errorf("cannot grant privileges on a %s", g.ObjectType)
+	}
+	for _, v := range g.Privileges {
+		if !isGrantPrivilege(g.ObjectType, v) {
+			return fmt.Errorf("privilege '%s' cannot be granted on a %s, should be one of: %s", v, g.ObjectType, strings.Join(grantPrivileges[g.ObjectType], ", "))
+		}
+	}
+	return nil
+}
+
+func isGrantPrivilege(objectType, privilege string) bool {
+	for _, v := range grantPrivileges[objectType] {
+		if v == privilege {
+			return true
+		}
+	}
+	return false
+}
+
+// target returns the ON clause of the GRANT and REVOKE statements.
+func (g *grant) target() string {
+	switch g.ObjectType {
+	case "database":
+		return "DATABASE " + pgx.Identifier{g.Database}.Sanitize()
+	case "schema":
+		return "SCHEMA " + pgx.Identifier{g.Schema}.Sanitize()
+	}
+	if len(g.Objects) == 0 {
+		return fmt.Sprintf("ALL %sS IN SCHEMA %s", strings.ToUpper(g.ObjectType), pgx.Identifier{g.Schema}.Sanitize())
+	}
+	objects := []string{}
+	for _, v := range g.Objects {
+		objects = append(objects, pgx.Identifier{g.Schema, v}.Sanitize())
+	}
+	return strings.ToUpper(g.ObjectType) + " " + strings.Join(objects, ", ")
+}
+
+func (g *grant) grantStatement() string {
+	return fmt.Sprintf("GRANT %s ON %s TO %s", strings.Join(g.Privileges, ", "), g.target(), pgx.Identifier{g.Role}.Sanitize())
+}
+
+func (g *grant) revokeStatement() string {
+	return fmt.Sprintf("REVOKE ALL ON %s FROM %s", g.target(), pgx.Identifier{g.Role}.Sanitize())
+}
+
+// grantObjectsFrom selects the tables or sequences a grant covers, given the
+// schema, the relkinds and the object names as parameters numbered from first.
+func grantObjectsFrom(first int) string {
+	return fmt.Sprintf(`FROM pg_class c JOIN pg_namespace n ON n.oid = c.relnamespace
+WHERE n.nspname = $%d AND c.relkind::text = ANY($%d) AND (cardinality($%d::text[]) = 0 OR c.relname = ANY($%d))`, first, first+1, first+2, first+2)
+}
+
+// readPrivileges returns the privileges the role holds on every object the
+// grant covers, as found in the catalog acls. A grant on all the tables or
+// sequences of a schema without any covers nothing, and is kept as granted.
+func (g *grant) readPrivileges(ctx context.Context, conn *pgx.Conn) ([]string, error) {
+	var query string
+	var args []any
+	switch g.ObjectType {
+	case "database":
+		query = `SELECT a.privilege_type
+FROM pg_database d CROSS JOIN LATERAL aclexplode(d.datacl) a JOIN pg_roles r ON r.oid = a.grantee
+WHERE d.datname = current_database() AND r.rolname = $1`
+		args = []any{g.Role}
+	case "schema":
+		query = `SELECT a.privilege_type
+FROM pg_namespace n CROSS JOIN LATERAL aclexplode(n.nspacl) a JOIN pg_roles r ON r.oid = a.grantee
+WHERE n.nspname = $2 AND r.rolname = $1`
+		args = []any{g.Role, g.Schema}
+	default:
+		objects := grantObjectsFrom(2)
+		query = `SELECT a.privilege_type
+FROM (SELECT c.oid, c.relacl ` + objects + `) c
+CROSS JOIN LATERAL aclexplode(c.relacl) a JOIN pg_roles r ON r.oid = a.grantee
+WHERE r.rolname = $1
+GROUP BY a.privilege_type
+HAVING count(DISTINCT c.oid) = (SELECT count(*) ` + objects + `)`
+		objectNames := g.Objects
+		if objectNames == nil {
+			objectNames = []string{}
+		}
+		args = []any{g.Role, g.Schema, grantRelkinds[g.ObjectType], objectNames}
+		if len(g.Objects) == 0 {
+			var count int
+			err := conn.QueryRow(ctx, "SELECT count(*) "+grantObjectsFrom(1), args[1:]...).Scan(&count)
+			if err != nil {
+				return nil, err
+			}
+			if count == 0 {
+				return g.Privileges, nil
+			}
+		}
+	}
+	rows, err := conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	privileges, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(privileges)
+	return privileges, nil
+}
+
+// apply replaces the privileges of the role on the grant objects.
+func (g *grant) apply(ctx context.Context, conn *pgx.Conn) error {
+	if err := g.validate(); err != nil {
+		return err
+	}
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	if _, err := tx.Exec(ctx, g.revokeStatement()); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, g.grantStatement()); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (g *grant) id(projectID, branchID string) string {
+	parts := []string{projectID, branchID, g.Database, g.Role, g.ObjectType}
+	if g.ObjectType != "database" {
+		parts = append(parts, g.Schema)
+	}
+	if len(g.Objects) != 0 {
+		parts = append(parts, strings.Join(g.Objects, ","))
+	}
+	return strings.Join(parts, "/")
+}
+
+func (r grantResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_grant"
+}
+
+func (r grantResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version:             1,
+		MarkdownDescription: "Privileges of a role on a database, schema, tables or sequences, managed by connecting to the branch read_write endpoint as the database owner",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "grant identifier",
+				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "project id",
+				Required:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"branch_id": schema.StringAttribute{
+				MarkdownDescription: "branch id",
+				Required:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"database_name": schema.StringAttribute{
+				MarkdownDescription: "database name",
+				Required:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"role": schema.StringAttribute{
+				MarkdownDescription: "role receiving the privileges",
+				Required:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"schema": schema.StringAttribute{
+				MarkdownDescription: "schema name, required unless object_type is database",
+				Optional:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"object_type": schema.StringAttribute{
+				MarkdownDescription: "one of database, schema, table or sequence",
+				Required:            true,
+				Validators:          []validator.String{stringvalidator.OneOf("database", "schema", "table", "sequence")},
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"objects": schema.SetAttribute{
+				MarkdownDescription: "table or sequence names in the schema, all of them when empty",
+				Optional:            true,
+				ElementType:         types.StringType,
+				PlanModifiers:       []planmodifier.Set{setplanmodifier.RequiresReplace()},
+			},
+			"privileges": schema.SetAttribute{
+				MarkdownDescription: "privileges to grant, e.g. SELECT or USAGE",
+				Required:            true,
+				ElementType:         types.StringType,
+				Validators:          []validator.Set{setvalidator.SizeAtLeast(1)},
+			},
+		},
+	}
+}
+
+func (r grantResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: upgradeFromV0(r),
+	}
+}
+
+func (r grantResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data grantResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.ObjectType.IsUnknown() || data.ObjectType.IsNull() {
+		return
+	}
+	objectType := data.ObjectType.ValueString()
+	if objectType == "database" && !data.Schema.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("schema"), "Invalid schema", "schema cannot be set when object_type is database")
+	}
+	if objectType != "database" && data.Schema.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("schema"), "Missing schema", fmt.Sprintf("schema is required when object_type is %s", objectType))
+	}
+	if (objectType == "database" || objectType == "schema") && !data.Objects.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("objects"), "Invalid objects", fmt.Sprintf("objects cannot be set when object_type is %s", objectType))
+	}
+	if data.Privileges.IsUnknown() || data.Privileges.IsNull() {
+		return
+	}
+	for _, v := range data.Privileges.Elements() {
+		privilege, ok := v.(types.String)
+		if !ok || privilege.IsUnknown() {
+			continue
+		}
+		if !isGrantPrivilege(objectType, privilege.ValueString()) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("privileges"),
+				"Invalid privilege",
+				fmt.Sprintf("Privilege '%s' cannot be granted on a %s, should be one of: %s", privilege.ValueString(), objectType, strings.Join(grantPrivileges[objectType], ", ")),
+			)
+		}
+	}
+}
+
+func (r grantResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data grantResourceModel
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	g, err := data.toGrant(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read grant plan", err.Error())
+		return
+	}
+	conn, err := openDatabase(ctx, r.client, data.ProjectID.ValueString(), data.BranchID.ValueString(), data.DatabaseName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to connect to database", err.Error())
+		return
+	}
+	defer conn.Close(ctx)
+
+	err = g.apply(ctx, conn)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create grant", err.Error())
+		return
+	}
+	data.ID = types.StringValue(g.id(data.ProjectID.ValueString(), data.BranchID.ValueString()))
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.State, resp.Identity)...)
+}
+
+func (r grantResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data grantResourceModel
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	g, err := data.toGrant(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read grant state", err.Error())
+		return
+	}
+	conn, err := openDatabase(ctx, r.client, data.ProjectID.ValueString(), data.BranchID.ValueString(), data.DatabaseName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to connect to database", err.Error())
+		return
+	}
+	defer conn.Close(ctx)
+
+	privileges, err := g.readPrivileges(ctx, conn)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read grant", err.Error())
+		return
+	}
+	if len(privileges) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	aux, diags := types.SetValueFrom(ctx, types.StringType, privileges)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Privileges = aux
+	data.ID = types.StringValue(g.id(data.ProjectID.ValueString(), data.BranchID.ValueString()))
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.State, resp.Identity)...)
+}
+
+func (r grantResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data grantResourceModel
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	g, err := data.toGrant(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read grant plan", err.Error())
+		return
+	}
+	conn, err := openDatabase(ctx, r.client, data.ProjectID.ValueString(), data.BranchID.ValueString(), data.DatabaseName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to connect to database", err.Error())
+		return
+	}
+	defer conn.Close(ctx)
+
+	err = g.apply(ctx, conn)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to update grant", err.Error())
+		return
+	}
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.State, resp.Identity)...)
+}
+
+func (r grantResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data grantResourceModel
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	g, err := data.toGrant(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read grant state", err.Error())
+		return
+	}
+	conn, err := openDatabase(ctx, r.client, data.ProjectID.ValueString(), data.BranchID.ValueString(), data.DatabaseName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to connect to database", err.Error())
+		return
+	}
+	defer conn.Close(ctx)
+
+	_, err = conn.Exec(ctx, g.revokeStatement())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to delete grant", err.Error())
+		return
+	}
+	resp.State.RemoveResource(ctx)
+}
+
+// parseGrantID splits a grant id, as built by grant.id, into the attributes
+// of the grant.
+func parseGrantID(id string) (map[string]string, []string, error) {
+	invalid := fmt.Errorf("invalid id '%s' specified, should be in format %q, %q or %q", id,
+		"project_id/branch_id/database_name/role/database",
+		"project_id/branch_id/database_name/role/object_type/schema",
+		"project_id/branch_id/database_name/role/object_type/schema/object,object")
+	parts := strings.Split(id, "/")
+	if len(parts) < 5 || len(parts) > 7 {
+		return nil, nil, invalid
+	}
+	for _, v := range parts {
+		if v == "" {
+			return nil, nil, invalid
+		}
+	}
+	attributes := map[string]string{
+		"project_id":    parts[0],
+		"branch_id":     parts[1],
+		"database_name": parts[2],
+		"role":          parts[3],
+		"object_type":   parts[4],
+	}
+	objectType := parts[4]
+	switch {
+	case objectType == "database" && len(parts) == 5:
+		return attributes, nil, nil
+	case (objectType == "schema" && len(parts) == 6) || ((objectType == "table" || objectType == "sequence") && len(parts) >= 6):
+		attributes["schema"] = parts[5]
+	default:
+		return nil, nil, invalid
+	}
+	if len(parts) == 7 {
+		return attributes, strings.Split(parts[6], ","), nil
+	}
+	return attributes, nil, nil
+}
+
+func (r grantResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identitySchema("id")
+}
+
+// ImportState reads the grant attributes from its id, the privileges are then
+// read from the catalog.
+func (r grantResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := req.ID
+	if id == "" && req.Identity != nil {
+		var identityID types.String
+		resp.Diagnostics.Append(req.Identity.GetAttribute(ctx, path.Root("id"), &identityID)...)
+		id = identityID.ValueString()
+	}
+	attributes, objects, err := parseGrantID(id)
+	if err != nil {
+		resp.Diagnostics.AddError("Cannot import grant", err.Error())
+		return
+	}
+	attributes["id"] = id
+	setImportAttributes(ctx, attributes, resp)
+	if objects != nil {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("objects"), objects)...)
+	}
+}
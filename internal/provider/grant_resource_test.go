@@ -0,0 +1,185 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestGrantStatements(t *testing.T) {
+	tests := []struct {
+		grant  grant
+		want   string
+		revoke string
+	}{
+		{
+			grant:  grant{Database: "app", Role: "reader", ObjectType: "database", Privileges: []string{"CONNECT"}},
+			want:   `GRANT CONNECT ON DATABASE "app" TO "reader"`,
+			revoke: `REVOKE ALL ON DATABASE "app" FROM "reader"`,
+		},
+		{
+			grant:  grant{Role: "reader", Schema: "public", ObjectType: "schema", Privileges: []string{"USAGE"}},
+			want:   `GRANT USAGE ON SCHEMA "public" TO "reader"`,
+			revoke: `REVOKE ALL ON SCHEMA "public" FROM "reader"`,
+		},
+		{
+			grant:  grant{Role: "reader", Schema: "public", ObjectType: "table", Privileges: []string{"INSERT", "SELECT"}},
+			want:   `GRANT INSERT, SELECT ON ALL TABLES IN SCHEMA "public" TO "reader"`,
+			revoke: `REVOKE ALL ON ALL TABLES IN SCHEMA "public" FROM "reader"`,
+		},
+		{
+			grant:  grant{Role: "Reader", Schema: "public", ObjectType: "sequence", Objects: []string{"a_seq", "b\"seq"}, Privileges: []string{"USAGE"}},
+			want:   `GRANT USAGE ON SEQUENCE "public"."a_seq", "public"."b""seq" TO "Reader"`,
+			revoke: `REVOKE ALL ON SEQUENCE "public"."a_seq", "public"."b""seq" FROM "Reader"`,
+		},
+	}
+	for _, tt := range tests {
+		if got := tt.grant.grantStatement(); got != tt.want {
+			t.Errorf("grantStatement() = %s, want %s", got, tt.want)
+		}
+		if got := tt.grant.revokeStatement(); got != tt.revoke {
+			t.Errorf("revokeStatement() = %s, want %s", got, tt.revoke)
+		}
+	}
+}
+
+func TestGrantResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckPostgres(t,
+				"DO $$ BEGIN CREATE ROLE tf_grant_role; EXCEPTION WHEN duplicate_object THEN NULL; END $$",
+				"CREATE TABLE IF NOT EXISTS public.tf_grant_a (id int primary key)",
+				"CREATE SCHEMA IF NOT EXISTS tf_grant_empty",
+			)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testGrantResource(`["SELECT"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_grant.test", "privileges.#", "1"),
+					resource.TestCheckTypeSetElemAttr("neon_grant.test", "privileges.*", "SELECT"),
+					resource.TestCheckResourceAttr("neon_grant.usage", "privileges.#", "1"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "neon_grant.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// A grant on all the tables of a schema without any is kept
+			{
+				Config:   testGrantResource(`["SELECT"]`),
+				PlanOnly: true,
+			},
+			// Update and Read testing
+			{
+				Config: testGrantResource(`["SELECT", "INSERT"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_grant.test", "privileges.#", "2"),
+					resource.TestCheckTypeSetElemAttr("neon_grant.test", "privileges.*", "INSERT"),
+				),
+			},
+		},
+	})
+}
+
+func testGrantResource(privileges string) string {
+	return `
+resource "neon_grant" "usage" {
+	project_id = "project"
+	branch_id = "branch"
+	database_name = "database"
+	role = "tf_grant_role"
+	object_type = "schema"
+	schema = "public"
+	privileges = ["USAGE"]
+}
+
+resource "neon_grant" "test" {
+	project_id = "project"
+	branch_id = "branch"
+	database_name = "database"
+	role = "tf_grant_role"
+	object_type = "table"
+	schema = "public"
+	objects = ["tf_grant_a"]
+	privileges = ` + privileges + `
+}
+
+resource "neon_grant" "empty" {
+	project_id = "project"
+	branch_id = "branch"
+	database_name = "database"
+	role = "tf_grant_role"
+	object_type = "table"
+	schema = "tf_grant_empty"
+	privileges = ["SELECT"]
+}
+`
+}
+
+func TestGrantRejectsInvalidPrivileges(t *testing.T) {
+	ctx := context.Background()
+	privileges, _ := types.SetValueFrom(ctx, types.StringType, []string{"SELECT", "SELECT ON pg_authid TO public; --"})
+	m := grantResourceModel{
+		Role:       types.StringValue("reader"),
+		Schema:     types.StringValue("public"),
+		ObjectType: types.StringValue("table"),
+		Objects:    types.SetNull(types.StringType),
+		Privileges: privileges,
+	}
+	if _, err := m.toGrant(ctx); err == nil {
+		t.Errorf("toGrant() error = nil, want the invalid privilege rejected")
+	}
+	g := &grant{Role: "reader", ObjectType: "schema", Schema: "public", Privileges: []string{"SELECT"}}
+	if err := g.validate(); err == nil {
+		t.Errorf("validate() of SELECT on a schema error = nil")
+	}
+	m.Privileges = types.SetNull(types.StringType)
+	if _, err := m.toGrant(ctx); err != nil {
+		t.Errorf("toGrant() of an imported grant error = %s", err)
+	}
+}
+
+func TestParseGrantID(t *testing.T) {
+	tests := []struct {
+		id      string
+		want    string
+		objects []string
+	}{
+		{"p/b/app/reader/database", "map[branch_id:b database_name:app object_type:database project_id:p role:reader]", nil},
+		{"p/b/app/reader/schema/public", "map[branch_id:b database_name:app object_type:schema project_id:p role:reader schema:public]", nil},
+		{"p/b/app/reader/table/public", "map[branch_id:b database_name:app object_type:table project_id:p role:reader schema:public]", nil},
+		{"p/b/app/reader/sequence/public/a_seq,b_seq", "map[branch_id:b database_name:app object_type:sequence project_id:p role:reader schema:public]", []string{"a_seq", "b_seq"}},
+		{"p/b/app/reader/database/public", "", nil},
+		{"p/b/app/reader/schema/public/a", "", nil},
+		{"p/b/app/reader", "", nil},
+		{"p/b//reader/table/public", "", nil},
+	}
+	for _, tt := range tests {
+		attributes, objects, err := parseGrantID(tt.id)
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("parseGrantID(%s) error = nil", tt.id)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseGrantID(%s) error = %s", tt.id, err)
+			continue
+		}
+		if fmt.Sprint(attributes) != tt.want || fmt.Sprint(objects) != fmt.Sprint(tt.objects) {
+			t.Errorf("parseGrantID(%s) = %v %v, want %s %v", tt.id, attributes, objects, tt.want, tt.objects)
+		}
+		g := grant{Database: attributes["database_name"], Role: attributes["role"], Schema: attributes["schema"], ObjectType: attributes["object_type"], Objects: objects}
+		if got := g.id(attributes["project_id"], attributes["branch_id"]); got != tt.id {
+			t.Errorf("grant.id() = %s, want %s", got, tt.id)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// identitySchema returns an identity made of the string attributes names,
+// named after the state attributes they are copied from.
+func identitySchema(names ...string) identityschema.Schema {
+	attributes := map[string]identityschema.Attribute{}
+	for _, v := range names {
+		attributes[v] = identityschema.StringAttribute{
+			RequiredForImport: true,
+		}
+	}
+	return identityschema.Schema{
+		Attributes: attributes,
+	}
+}
+
+type attributeGetter func(context.Context, path.Path, interface{}) diag.Diagnostics
+type attributeSetter func(context.Context, path.Path, interface{}) diag.Diagnostics
+
+// copyIdentityAttribute copies an identity attribute, the identities are made
+// of string attributes but for the int64 id of api keys.
+func copyIdentityAttribute(ctx context.Context, name string, attribute interface{}, get attributeGetter, set attributeSetter) diag.Diagnostics {
+	if _, ok := attribute.(identityschema.Int64Attribute); ok {
+		var value types.Int64
+		diags := get(ctx, path.Root(name), &value)
+		return append(diags, set(ctx, path.Root(name), value)...)
+	}
+	var value types.String
+	diags := get(ctx, path.Root(name), &value)
+	return append(diags, set(ctx, path.Root(name), value)...)
+}
+
+// setIdentity copies the identity attributes from state, it does nothing when
+// the client does not support identities or the resource was removed.
+func setIdentity(ctx context.Context, state tfsdk.State, identity *tfsdk.ResourceIdentity) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+	if identity == nil || state.Raw.IsNull() {
+		return diags
+	}
+	for name, attribute := range identity.Schema.GetAttributes() {
+		diags.Append(copyIdentityAttribute(ctx, name, attribute, state.GetAttribute, identity.SetAttribute)...)
+	}
+	return diags
+}
+
+// importIdentity copies the identity attributes into state, for imports done
+// with an identity instead of a string id.
+func importIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, state *tfsdk.State) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+	if identity == nil {
+		diags.AddError("Missing import id", "either an import id or an identity should be specified")
+		return diags
+	}
+	for name, attribute := range identity.Schema.GetAttributes() {
+		diags.Append(copyIdentityAttribute(ctx, name, attribute, identity.GetAttribute, state.SetAttribute)...)
+	}
+	return diags
+}
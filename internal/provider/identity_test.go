@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestResourceIdentities(t *testing.T) {
+	ctx := context.Background()
+	p := New("test")()
+	for _, factory := range p.Resources(ctx) {
+		r := factory()
+		metadata := resource.MetadataResponse{}
+		r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "neon"}, &metadata)
+		if _, ok := r.(resource.ResourceWithImportState); !ok {
+			t.Errorf("%s cannot be imported", metadata.TypeName)
+		}
+		withIdentity, ok := r.(resource.ResourceWithIdentity)
+		if !ok {
+			t.Errorf("%s has no identity", metadata.TypeName)
+			continue
+		}
+
+		schema := resource.SchemaResponse{}
+		r.Schema(ctx, resource.SchemaRequest{}, &schema)
+		identitySchema := resource.IdentitySchemaResponse{}
+		withIdentity.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &identitySchema)
+		if len(identitySchema.IdentitySchema.Attributes) == 0 {
+			t.Errorf("%s identity has no attributes", metadata.TypeName)
+		}
+
+		// every identity attribute is an attribute of the resource, so an
+		// import by identity followed by a read gives back the same identity
+		values := map[string]tftypes.Value{}
+		for name, attribute := range identitySchema.IdentitySchema.Attributes {
+			if _, ok := schema.Schema.Attributes[name]; !ok {
+				t.Errorf("%s identity attribute %s is not in the resource schema", metadata.TypeName, name)
+				continue
+			}
+			if _, ok := attribute.(identityschema.Int64Attribute); ok {
+				values[name] = tftypes.NewValue(tftypes.Number, big.NewFloat(42))
+				continue
+			}
+			values[name] = tftypes.NewValue(tftypes.String, name+"-value")
+		}
+		if t.Failed() {
+			continue
+		}
+		identity := &tfsdk.ResourceIdentity{
+			Schema: identitySchema.IdentitySchema,
+			Raw:    tftypes.NewValue(identitySchema.IdentitySchema.Type().TerraformType(ctx), values),
+		}
+		state := tfsdk.State{
+			Schema: schema.Schema,
+			Raw:    tftypes.NewValue(schema.Schema.Type().TerraformType(ctx), nil),
+		}
+		diags := importIdentity(ctx, identity, &state)
+		if diags.HasError() {
+			t.Errorf("%s importIdentity() = %v", metadata.TypeName, diags)
+			continue
+		}
+
+		read := &tfsdk.ResourceIdentity{
+			Schema: identitySchema.IdentitySchema,
+			Raw:    tftypes.NewValue(identitySchema.IdentitySchema.Type().TerraformType(ctx), nil),
+		}
+		diags = setIdentity(ctx, state, read)
+		if diags.HasError() {
+			t.Errorf("%s setIdentity() = %v", metadata.TypeName, diags)
+			continue
+		}
+		got := map[string]tftypes.Value{}
+		if err := read.Raw.As(&got); err != nil {
+			t.Errorf("%s identity: %s", metadata.TypeName, err)
+			continue
+		}
+		for name, value := range values {
+			if !got[name].Equal(value) {
+				t.Errorf("%s identity %s = %s, want %s", metadata.TypeName, name, got[name], value)
+			}
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// parseImportID splits id into the attributes named by the first of formats,
+// like "project_id/branch_id/name", with as many parts.
+func parseImportID(id string, formats ...string) (map[string]string, error) {
+	quoted := []string{}
+	for _, v := range formats {
+		quoted = append(quoted, fmt.Sprintf("%q", v))
+	}
+	invalid := fmt.Errorf("invalid id '%s' specified, should be in format %s", id, strings.Join(quoted, " or "))
+	for _, format := range formats {
+		names := strings.Split(format, "/")
+		parts := strings.Split(id, "/")
+		if len(parts) != len(names) {
+			continue
+		}
+		attributes := map[string]string{}
+		for i, v := range names {
+			if parts[i] == "" {
+				return nil, fmt.Errorf("%w, %s is empty", invalid, v)
+			}
+			attributes[v] = parts[i]
+		}
+		return attributes, nil
+	}
+	return nil, invalid
+}
+
+// importState sets the attributes parsed from the import id, or copied from
+// the identity when the import is done without an id.
+func importState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse, what string, formats ...string) {
+	if req.ID == "" {
+		resp.Diagnostics.Append(importIdentity(ctx, req.Identity, &resp.State)...)
+		return
+	}
+	attributes, err := parseImportID(req.ID, formats...)
+	if err != nil {
+		resp.Diagnostics.AddError("Cannot import "+what, err.Error())
+		return
+	}
+	setImportAttributes(ctx, attributes, resp)
+}
+
+func setImportAttributes(ctx context.Context, attributes map[string]string, resp *resource.ImportStateResponse) {
+	for name, value := range attributes {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(name), value)...)
+	}
+}
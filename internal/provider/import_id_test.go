@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseImportID(t *testing.T) {
+	for _, v := range []struct {
+		id      string
+		formats []string
+		want    map[string]string
+		err     string
+	}{
+		{"p-1/br-1/app", []string{"project_id/branch_id/name"}, map[string]string{"project_id": "p-1", "branch_id": "br-1", "name": "app"}, ""},
+		{"p-1/ep-1", []string{"project_id/id"}, map[string]string{"project_id": "p-1", "id": "ep-1"}, ""},
+		{"p-1", []string{"id"}, map[string]string{"id": "p-1"}, ""},
+		{"ep-1", []string{"project_id/id"}, nil, `should be in format "project_id/id"`},
+		{"p-1/br-1", []string{"project_id/branch_id/name"}, nil, `should be in format "project_id/branch_id/name"`},
+		{"p-1//app", []string{"project_id/branch_id/name"}, nil, "branch_id is empty"},
+		{"", []string{"project_id/id", "id/project_id"}, nil, `"project_id/id" or "id/project_id"`},
+		// formats with as many parts are never told apart, the first one wins
+		{"br-1/p-1", []string{"project_id/id", "id/project_id"}, map[string]string{"project_id": "br-1", "id": "p-1"}, ""},
+	} {
+		got, err := parseImportID(v.id, v.formats...)
+		if v.err != "" {
+			if err == nil || !strings.Contains(err.Error(), v.err) {
+				t.Errorf("parseImportID(%q) error = %v, want it to contain %s", v.id, err, v.err)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(got, v.want) {
+			t.Errorf("parseImportID(%q) = %v, %v, want %v", v.id, got, err, v.want)
+		}
+	}
+}
+
+func TestParseBranchImportID(t *testing.T) {
+	for _, v := range []struct {
+		id   string
+		want map[string]string
+		err  string
+	}{
+		{"p-1/br-1", map[string]string{"project_id": "p-1", "id": "br-1"}, ""},
+		{"br-1/p-1", map[string]string{"project_id": "p-1", "id": "br-1"}, ""},
+		{"br-1", nil, `should be in format "project_id/id" or "id/project_id"`},
+		{"p-1", nil, `should be in format "project_id/id" or "id/project_id"`},
+		{"br-1/", nil, "project_id is empty"},
+		{"p-1/", nil, "id is empty"},
+		{"p-1/br-1/main", nil, `should be in format "project_id/id" or "id/project_id"`},
+	} {
+		got, err := parseBranchImportID(v.id)
+		if v.err != "" {
+			if err == nil || !strings.Contains(err.Error(), v.err) {
+				t.Errorf("parseBranchImportID(%q) error = %v, want it to contain %s", v.id, err, v.err)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(got, v.want) {
+			t.Errorf("parseBranchImportID(%q) = %v, %v, want %v", v.id, got, err, v.want)
+		}
+	}
+}
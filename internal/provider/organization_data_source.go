@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &organizationDataSource{}
+
+type organizationDataSource struct {
+	client *resty.Client
+}
+
+type organizationDataModel struct {
+	ID        types.String `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	Handle    types.String `tfsdk:"handle"`
+	Plan      types.String `tfsdk:"plan"`
+	CreatedAt types.String `tfsdk:"created_at"`
+	UpdatedAt types.String `tfsdk:"updated_at"`
+}
+
+type organizationDataJSON struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Handle    string `json:"handle"`
+	Plan      string `json:"plan"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+func (in *organizationDataJSON) ToOrganizationDataModel() *organizationDataModel {
+	return &organizationDataModel{
+		ID:        types.StringValue(in.ID),
+		Name:      types.StringValue(in.Name),
+		Handle:    types.StringValue(in.Handle),
+		Plan:      types.StringValue(in.Plan),
+		CreatedAt: types.StringValue(in.CreatedAt),
+		UpdatedAt: types.StringValue(in.UpdatedAt),
+	}
+}
+
+// Metadata implements datasource.DataSource
+func (*organizationDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization"
+}
+
+// Read implements datasource.DataSource
+func (d *organizationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data organizationDataModel
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, _ := d.client.R().Get(fmt.Sprintf("/organizations/%s", data.ID.ValueString()))
+	if response.IsError() {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to read organization with a status code: %s", response.Status()), "")
+		return
+	}
+	organization := organizationDataJSON{}
+	err := json.Unmarshal(response.Body(), &organization)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to unmarshal response", err.Error())
+		return
+	}
+
+	plan := organization.ToOrganizationDataModel()
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Schema implements datasource.DataSource
+func (*organizationDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "organization id",
+				Required:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "organization name",
+				Computed:            true,
+			},
+			"handle": schema.StringAttribute{
+				MarkdownDescription: "organization handle",
+				Computed:            true,
+			},
+			"plan": schema.StringAttribute{
+				MarkdownDescription: "billing plan",
+				Computed:            true,
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "created at",
+				Computed:            true,
+			},
+			"updated_at": schema.StringAttribute{
+				MarkdownDescription: "updated at",
+				Computed:            true,
+			},
+		},
+	}
+}
@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestOrganizationDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckOrganization(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testOrganizationDataSource(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.neon_organization.test", "id", os.Getenv("NEON_ORG_ID")),
+					resource.TestCheckResourceAttrSet("data.neon_organization.test", "name"),
+					resource.TestCheckResourceAttr("neon_project.test", "org_id", os.Getenv("NEON_ORG_ID")),
+				),
+			},
+		},
+	})
+}
+
+func testOrganizationDataSource() string {
+	return `
+provider "neon" {
+	default_org_id = "` + os.Getenv("NEON_ORG_ID") + `"
+}
+
+resource "neon_project" "test" {
+	name = "name_project"
+}
+
+data "neon_organization" "test" {
+	id = neon_project.test.org_id
+}
+`
+}
@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+)
+
+// The attribute maps are shared with the computed nested attributes of other
+// resources, these helpers add plan modifiers to a resource's own copy.
+
+func addStringPlanModifiers(attributes map[string]schema.Attribute, name string, modifiers ...planmodifier.String) {
+	attribute := attributes[name].(schema.StringAttribute)
+	attribute.PlanModifiers = append(attribute.PlanModifiers, modifiers...)
+	attributes[name] = attribute
+}
+
+func addInt64PlanModifiers(attributes map[string]schema.Attribute, name string, modifiers ...planmodifier.Int64) {
+	attribute := attributes[name].(schema.Int64Attribute)
+	attribute.PlanModifiers = append(attribute.PlanModifiers, modifiers...)
+	attributes[name] = attribute
+}
+
+func addBoolPlanModifiers(attributes map[string]schema.Attribute, name string, modifiers ...planmodifier.Bool) {
+	attribute := attributes[name].(schema.BoolAttribute)
+	attribute.PlanModifiers = append(attribute.PlanModifiers, modifiers...)
+	attributes[name] = attribute
+}
+
+func addMapNestedPlanModifiers(attributes map[string]schema.Attribute, name string, modifiers ...planmodifier.Map) {
+	attribute := attributes[name].(schema.MapNestedAttribute)
+	attribute.PlanModifiers = append(attribute.PlanModifiers, modifiers...)
+	attributes[name] = attribute
+}
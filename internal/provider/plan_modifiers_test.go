@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+)
+
+func TestReplacedAttributes(t *testing.T) {
+	ctx := context.Background()
+	for _, v := range []struct {
+		resource   resource.Resource
+		attributes []string
+	}{
+		{projectResource{}, []string{"region_id", "pg_version", "engine"}},
+		{branchResource{}, []string{"project_id"}},
+		{endpointResource{}, []string{"project_id", "type", "region_id"}},
+	} {
+		resp := resource.SchemaResponse{}
+		v.resource.Schema(ctx, resource.SchemaRequest{}, &resp)
+		for _, name := range v.attributes {
+			attribute, ok := resp.Schema.Attributes[name]
+			if !ok {
+				t.Errorf("%T has no attribute %s", v.resource, name)
+				continue
+			}
+			var descriptions []string
+			switch a := attribute.(type) {
+			case schema.StringAttribute:
+				for _, m := range a.PlanModifiers {
+					descriptions = append(descriptions, m.Description(ctx))
+				}
+			case schema.Int64Attribute:
+				for _, m := range a.PlanModifiers {
+					descriptions = append(descriptions, m.Description(ctx))
+				}
+			}
+			if !containsString(descriptions, "If the value of this attribute changes, Terraform will destroy and recreate the resource.") {
+				t.Errorf("%T %s plan modifiers = %v, want it to require replacement", v.resource, name, descriptions)
+			}
+		}
+	}
+
+	// the attribute maps shared with the computed project attributes are left
+	// untouched
+	for name, attribute := range endpointResourceAttr() {
+		if a, ok := attribute.(schema.StringAttribute); ok && len(a.PlanModifiers) > 0 {
+			t.Errorf("endpointResourceAttr() %s has plan modifiers", name)
+		}
+	}
+}
@@ -111,7 +111,7 @@ func (*projectDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 			"pg_version": schema.Int64Attribute{
 				MarkdownDescription: "neon host",
 				Computed:            true,
-				Validators:          []validator.Int64{int64validator.Any(int64validator.OneOf(14), int64validator.OneOf(15))},
+				Validators:          []validator.Int64{int64validator.OneOf(14, 15, 16, 17)},
 			},
 			"last_active": schema.StringAttribute{
 				MarkdownDescription: "last active",
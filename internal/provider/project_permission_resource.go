@@ -0,0 +1,205 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type projectPermissionResource struct {
+	client *resty.Client
+}
+type projectPermissionJSON struct {
+	ID             string `json:"id"`
+	GrantedToEmail string `json:"granted_to_email"`
+	GrantedAt      string `json:"granted_at"`
+	RevokedAt      string `json:"revoked_at,omitempty"`
+}
+type projectPermissionResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	ProjectID types.String `tfsdk:"project_id"`
+	Email     types.String `tfsdk:"email"`
+	GrantedAt types.String `tfsdk:"granted_at"`
+}
+
+var _ resource.Resource = projectPermissionResource{}
+var _ resource.ResourceWithUpgradeState = projectPermissionResource{}
+var _ resource.ResourceWithImportState = projectPermissionResource{}
+var _ resource.ResourceWithIdentity = projectPermissionResource{}
+
+func (in *projectPermissionJSON) toProjectPermissionResourceModel(projectID string) *projectPermissionResourceModel {
+	return &projectPermissionResourceModel{
+		ID:        types.StringValue(in.ID),
+		ProjectID: types.StringValue(projectID),
+		Email:     types.StringValue(in.GrantedToEmail),
+		GrantedAt: types.StringValue(in.GrantedAt),
+	}
+}
+
+// listProjectPermissions returns the permissions of a project that have not
+// been revoked.
+func listProjectPermissions(client *resty.Client, projectID string) ([]projectPermissionJSON, error) {
+	response, err := client.R().Get(fmt.Sprintf("/projects/%s/permissions", projectID))
+	if err != nil {
+		return nil, err
+	}
+	if response.IsError() {
+		return nil, fmt.Errorf("failed to list project permissions with a status code: %s", response.Status())
+	}
+	inner := struct {
+		ProjectPermissions []projectPermissionJSON `json:"project_permissions"`
+	}{}
+	err = json.Unmarshal(response.Body(), &inner)
+	if err != nil {
+		return nil, err
+	}
+	permissions := []projectPermissionJSON{}
+	for _, v := range inner.ProjectPermissions {
+		if v.RevokedAt == "" {
+			permissions = append(permissions, v)
+		}
+	}
+	return permissions, nil
+}
+
+func (r projectPermissionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project_permission"
+}
+
+func (r projectPermissionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version:             1,
+		MarkdownDescription: "Neon project permission resource, shares a project with another user",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "permission id",
+				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "project id",
+				Required:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"email": schema.StringAttribute{
+				MarkdownDescription: "email of the user the project is shared with",
+				Required:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"granted_at": schema.StringAttribute{
+				MarkdownDescription: "granted at",
+				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+		},
+	}
+}
+
+func (r projectPermissionResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: upgradeFromV0(r),
+	}
+}
+
+func (r projectPermissionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data projectPermissionResourceModel
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	content := struct {
+		Email string `json:"email"`
+	}{
+		Email: data.Email.ValueString(),
+	}
+	response, _ := r.client.R().
+		SetBody(content).
+		Post(fmt.Sprintf("/projects/%s/permissions", data.ProjectID.ValueString()))
+	if response.IsError() {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to create project permission resource with a status code: %s", response.Status()), "")
+		return
+	}
+	inner := projectPermissionJSON{}
+	err := json.Unmarshal(response.Body(), &inner)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to unmarshal response", err.Error())
+		return
+	}
+	permission := inner.toProjectPermissionResourceModel(data.ProjectID.ValueString())
+	// Keep the email as configured, the api may normalize its case.
+	permission.Email = data.Email
+	diags = resp.State.Set(ctx, permission)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.State, resp.Identity)...)
+}
+
+func (r projectPermissionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data projectPermissionResourceModel
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	permissions, err := listProjectPermissions(r.client, data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read project permission resource", err.Error())
+		return
+	}
+	for _, v := range permissions {
+		if v.ID == data.ID.ValueString() || strings.EqualFold(v.GrantedToEmail, data.Email.ValueString()) {
+			permission := v.toProjectPermissionResourceModel(data.ProjectID.ValueString())
+			if strings.EqualFold(v.GrantedToEmail, data.Email.ValueString()) {
+				permission.Email = data.Email
+			}
+			diags = resp.State.Set(ctx, permission)
+			resp.Diagnostics.Append(diags...)
+			resp.Diagnostics.Append(setIdentity(ctx, resp.State, resp.Identity)...)
+			return
+		}
+	}
+	resp.State.RemoveResource(ctx)
+}
+
+func (r projectPermissionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data projectPermissionResourceModel
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.State, resp.Identity)...)
+}
+
+func (r projectPermissionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data projectPermissionResourceModel
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	response, _ := r.client.R().Delete(fmt.Sprintf("/projects/%s/permissions/%s", data.ProjectID.ValueString(), data.ID.ValueString()))
+	if response.IsError() {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to revoke project permission resource with a status code: %s", response.Status()), "")
+		return
+	}
+	resp.State.RemoveResource(ctx)
+}
+
+func (r projectPermissionResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identitySchema("project_id", "email")
+}
+
+func (r projectPermissionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importState(ctx, req, resp, "project permission", "project_id/email")
+}
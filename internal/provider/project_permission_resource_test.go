@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestProjectPermissionResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckGrantee(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testProjectPermissionResourceCreate(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_project_permission.test", "email", os.Getenv("NEON_GRANTEE_EMAIL")),
+					resource.TestCheckResourceAttrSet("neon_project_permission.test", "id"),
+					resource.TestCheckResourceAttr("data.neon_project_permissions.test", "permissions.#", "1"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "neon_project_permission.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					if rs, ok := s.RootModule().Resources["neon_project_permission.test"]; ok {
+						return fmt.Sprintf("%s/%s", rs.Primary.Attributes["project_id"], rs.Primary.Attributes["email"]), nil
+					}
+					return "", fmt.Errorf("cannot find neon_project_permission.test")
+				},
+			},
+		},
+	})
+}
+
+func testProjectPermissionResourceCreate() string {
+	return `
+resource "neon_project" "test" {
+	name = "name_project"
+}
+
+resource "neon_project_permission" "test" {
+	project_id = neon_project.test.id
+	email = "` + os.Getenv("NEON_GRANTEE_EMAIL") + `"
+}
+
+data "neon_project_permissions" "test" {
+	project_id = neon_project_permission.test.project_id
+}
+`
+}
@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &projectPermissionsDataSource{}
+
+type projectPermissionsDataSource struct {
+	client *resty.Client
+}
+
+type projectPermissionsDataModel struct {
+	ID          types.String `tfsdk:"id"`
+	ProjectID   types.String `tfsdk:"project_id"`
+	Permissions types.List   `tfsdk:"permissions"`
+}
+
+type projectPermissionDataModel struct {
+	ID        types.String `tfsdk:"id"`
+	Email     types.String `tfsdk:"email"`
+	GrantedAt types.String `tfsdk:"granted_at"`
+}
+
+func (in *projectPermissionJSON) ToProjectPermissionDataModel() *projectPermissionDataModel {
+	return &projectPermissionDataModel{
+		ID:        types.StringValue(in.ID),
+		Email:     types.StringValue(in.GrantedToEmail),
+		GrantedAt: types.StringValue(in.GrantedAt),
+	}
+}
+
+func projectPermissionsDataPermissionAttr() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			MarkdownDescription: "permission id",
+			Computed:            true,
+		},
+		"email": schema.StringAttribute{
+			MarkdownDescription: "email of the user the project is shared with",
+			Computed:            true,
+		},
+		"granted_at": schema.StringAttribute{
+			MarkdownDescription: "granted at",
+			Computed:            true,
+		},
+	}
+}
+
+// Metadata implements datasource.DataSource
+func (*projectPermissionsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project_permissions"
+}
+
+// Read implements datasource.DataSource
+func (d *projectPermissionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data projectPermissionsDataModel
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	permissions, err := listProjectPermissions(d.client, data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to list project permissions", err.Error())
+		return
+	}
+	p := []projectPermissionDataModel{}
+	for _, v := range permissions {
+		p = append(p, *v.ToProjectPermissionDataModel())
+	}
+	aux, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: typeFromDataAttrs(projectPermissionsDataPermissionAttr())}, p)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Permissions = aux
+	data.ID = data.ProjectID
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Schema implements datasource.DataSource
+func (*projectPermissionsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "placeholder identifier",
+				Computed:            true,
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "project id",
+				Required:            true,
+			},
+			"permissions": schema.ListNestedAttribute{
+				MarkdownDescription: "users the project is shared with",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: projectPermissionsDataPermissionAttr(),
+				},
+			},
+		},
+	}
+}
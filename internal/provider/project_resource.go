@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 
 	"github.com/go-resty/resty/v2"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
@@ -11,6 +12,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
@@ -21,31 +23,69 @@ import (
 )
 
 type projectResource struct {
-	client *resty.Client
+	client       *resty.Client
+	regions      *regionCache
+	defaultOrgID string
 }
 
 var _ resource.Resource = projectResource{}
+var _ resource.ResourceWithUpgradeState = projectResource{}
 var _ resource.ResourceWithImportState = projectResource{}
+var _ resource.ResourceWithIdentity = projectResource{}
+var _ resource.ResourceWithModifyPlan = projectResource{}
 
 func connectionUriResourceAttr() map[string]schema.Attribute {
 	return map[string]schema.Attribute{
 		"connection_uri": schema.StringAttribute{
-			Required: true,
+			MarkdownDescription: "connection uri",
+			Computed:            true,
+			Sensitive:           true,
+		},
+		"host": schema.StringAttribute{
+			MarkdownDescription: "endpoint host",
+			Computed:            true,
+		},
+		"port": schema.Int64Attribute{
+			MarkdownDescription: "endpoint port",
+			Computed:            true,
+		},
+		"database": schema.StringAttribute{
+			MarkdownDescription: "database name",
+			Computed:            true,
+		},
+		"user": schema.StringAttribute{
+			MarkdownDescription: "role name",
+			Computed:            true,
+		},
+		"password": schema.StringAttribute{
+			MarkdownDescription: "role password",
+			Computed:            true,
+			Sensitive:           true,
+		},
+		"pooled_host": schema.StringAttribute{
+			MarkdownDescription: "connection pooler host",
+			Computed:            true,
+		},
+		"pooled_connection_uri": schema.StringAttribute{
+			MarkdownDescription: "connection uri through the connection pooler",
+			Computed:            true,
+			Sensitive:           true,
 		},
 	}
 }
 
 func (r projectResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 2,
 		Attributes: map[string]schema.Attribute{
 			"autoscaling_limit_min_cu": schema.Int64Attribute{
-				MarkdownDescription: "autoscaling limit min",
+				MarkdownDescription: "autoscaling limit min, fractional limits set outside Terraform are rounded up",
 				Computed:            true,
 				Optional:            true,
 				PlanModifiers:       []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
 			},
 			"autoscaling_limit_max_cu": schema.Int64Attribute{
-				MarkdownDescription: "autoscaling limit max",
+				MarkdownDescription: "autoscaling limit max, fractional limits set outside Terraform are rounded up",
 				Computed:            true,
 				Optional:            true,
 				PlanModifiers:       []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
@@ -58,32 +98,63 @@ func (r projectResource) Schema(ctx context.Context, req resource.SchemaRequest,
 			"id": schema.StringAttribute{
 				MarkdownDescription: "neon host",
 				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 			},
 			"platform_id": schema.StringAttribute{
 				MarkdownDescription: "neon host",
 				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 			},
 			"region_id": schema.StringAttribute{
-				MarkdownDescription: "neon host",
+				MarkdownDescription: "region id, changing it replaces the project",
+				Computed:            true,
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"org_id": schema.StringAttribute{
+				MarkdownDescription: "organization id, changing it transfers the project to the new organization",
+				Computed:            true,
+				Optional:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"block_public_connections": schema.BoolAttribute{
+				MarkdownDescription: "only allow connections through vpc endpoints attached with neon_vpc_endpoint_restriction",
 				Computed:            true,
 				Optional:            true,
+				PlanModifiers:       []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
+			},
+			"enable_logical_replication": schema.BoolAttribute{
+				MarkdownDescription: "enable logical replication, it restarts the computes and cannot be disabled afterwards",
+				Computed:            true,
+				Optional:            true,
+				PlanModifiers:       []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
 			},
 			"name": schema.StringAttribute{
 				MarkdownDescription: "neon host",
 				Required:            true,
 			},
 			"engine": schema.StringAttribute{
-				MarkdownDescription: "neon host",
+				MarkdownDescription: "engine, changing it replaces the project",
 				Computed:            true,
 				Optional:            true,
 				Validators:          []validator.String{stringvalidator.OneOf("k8s-pod", "k8s-neonvm", "docker")},
-				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"pg_version": schema.Int64Attribute{
-				MarkdownDescription: "neon host",
+				MarkdownDescription: "postgres version, changing it replaces the project",
 				Optional:            true,
 				Computed:            true,
-				Validators:          []validator.Int64{int64validator.Any(int64validator.OneOf(14), int64validator.OneOf(15))},
+				Validators:          []validator.Int64{int64validator.OneOf(14, 15, 16, 17)},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+					int64planmodifier.RequiresReplace(),
+				},
 			},
 			"last_active": schema.StringAttribute{
 				MarkdownDescription: "last active",
@@ -92,6 +163,7 @@ func (r projectResource) Schema(ctx context.Context, req resource.SchemaRequest,
 			"created_at": schema.StringAttribute{
 				MarkdownDescription: "created at",
 				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 			},
 			"updated_at": schema.StringAttribute{
 				MarkdownDescription: "updated at",
@@ -137,13 +209,29 @@ func (r projectResource) Schema(ctx context.Context, req resource.SchemaRequest,
 	}
 }
 
+func (r projectResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	upgradeBranch := func(state map[string]interface{}) {
+		if branch, ok := state["branch"].(map[string]interface{}); ok {
+			upgradeBranchEndpoints(branch)
+			// The project branch only reports its endpoint details.
+			branch["endpoints"] = nil
+		}
+	}
+	return map[int64]resource.StateUpgrader{
+		0: upgradeRawState(r, upgradeBranch),
+		1: upgradeRawState(r, upgradeBranch),
+	}
+}
+
 type createProject struct {
-	Name                     string `json:"name,omitempty"`
-	Provisioner              string `json:"provisioner,omitempty"`
-	RegionID                 string `json:"region_id,omitempty"`
-	PgVersion                int64  `json:"pg_version,omitempty"`
-	Autoscaling_limit_min_cu int64  `json:"autoscaling_limit_min_cu,omitempty"`
-	Autoscaling_limit_max_cu int64  `json:"autoscaling_limit_max_cu,omitempty"`
+	Name                     string               `json:"name,omitempty"`
+	Provisioner              string               `json:"provisioner,omitempty"`
+	RegionID                 string               `json:"region_id,omitempty"`
+	OrgID                    string               `json:"org_id,omitempty"`
+	Settings                 *projectSettingsJSON `json:"settings,omitempty"`
+	PgVersion                int64                `json:"pg_version,omitempty"`
+	Autoscaling_limit_min_cu int64                `json:"autoscaling_limit_min_cu,omitempty"`
+	Autoscaling_limit_max_cu int64                `json:"autoscaling_limit_max_cu,omitempty"`
 }
 
 func (r projectResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -154,6 +242,10 @@ func (r projectResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	orgID := r.defaultOrgID
+	if !data.OrgID.IsUnknown() && !data.OrgID.IsNull() {
+		orgID = data.OrgID.ValueString()
+	}
 	content := struct {
 		Project createProject `json:"project"`
 	}{
@@ -161,6 +253,8 @@ func (r projectResource) Create(ctx context.Context, req resource.CreateRequest,
 			Name:                     data.Name.ValueString(),
 			Provisioner:              data.Provisioner.ValueString(),
 			RegionID:                 data.RegionID.ValueString(),
+			OrgID:                    orgID,
+			Settings:                 data.toProjectSettingsJSON(),
 			PgVersion:                data.PgVersion.ValueInt64(),
 			Autoscaling_limit_min_cu: data.AutoscalingLimitMinCu.ValueInt64(),
 			Autoscaling_limit_max_cu: data.AutoscalingLimitMaxCu.ValueInt64(),
@@ -212,6 +306,7 @@ func (r projectResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.State, resp.Identity)...)
 }
 
 // Delete implements resource.Resource
@@ -242,15 +337,14 @@ func (r projectResource) Read(ctx context.Context, req resource.ReadRequest, res
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	response, _ := r.client.R().Get(fmt.Sprintf("/projects/%s", data.ID.ValueString()))
-	if response.IsError() {
-		resp.Diagnostics.AddError(fmt.Sprintf("Failed to delete branch resource with a status code: %s", response.Status()), "")
+	passwords, diags := data.rolePasswords(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-	project := &projectResourceJSON{}
-	err := json.Unmarshal(response.Body(), &project)
+	project, err := readProject(r.client, data.ID.ValueString(), passwords)
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to unmarshal response", err.Error())
+		resp.Diagnostics.AddError("Failed to read project resource", err.Error())
 		return
 	}
 
@@ -261,12 +355,193 @@ func (r projectResource) Read(ctx context.Context, req resource.ReadRequest, res
 	}
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.State, resp.Identity)...)
+}
+
+// branchesPageLimit is the number of branches asked for per page.
+const branchesPageLimit = 100
+
+// findDefaultBranch walks the pages of the project branches until the default
+// branch, it returns nil when the project has none.
+func findDefaultBranch(client *resty.Client, projectID string) (*branchResourceJSON, error) {
+	cursor := ""
+	for {
+		request := client.R().SetQueryParam("limit", strconv.Itoa(branchesPageLimit))
+		if cursor != "" {
+			request.SetQueryParam("cursor", cursor)
+		}
+		response, err := request.Get(fmt.Sprintf("/projects/%s/branches", projectID))
+		if err != nil {
+			return nil, err
+		}
+		if response.IsError() {
+			return nil, fmt.Errorf("failed to list project branches with a status code: %s", response.Status())
+		}
+		page := struct {
+			Branches []struct {
+				branchResourceJSON
+				Default bool `json:"default"`
+				Primary bool `json:"primary"`
+			} `json:"branches"`
+			Pagination *struct {
+				Next string `json:"next"`
+			} `json:"pagination"`
+		}{}
+		err = json.Unmarshal(response.Body(), &page)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range page.Branches {
+			if v.Default || v.Primary {
+				branch := v.branchResourceJSON
+				return &branch, nil
+			}
+		}
+		if len(page.Branches) == 0 || page.Pagination == nil || page.Pagination.Next == "" || page.Pagination.Next == cursor {
+			return nil, nil
+		}
+		cursor = page.Pagination.Next
+	}
+}
+
+// readProject rebuilds the project as it is returned on creation, from the
+// project, its default branch and the branch endpoints, roles and databases.
+// Role passwords found in passwords are kept, only the password of the
+// database owner is revealed when missing.
+func readProject(client *resty.Client, projectID string, passwords map[string]string) (*projectResourceJSON, error) {
+	response, err := client.R().Get(fmt.Sprintf("/projects/%s", projectID))
+	if err != nil {
+		return nil, err
+	}
+	if response.IsError() {
+		return nil, fmt.Errorf("failed to get project with a status code: %s", response.Status())
+	}
+	project := &projectResourceJSON{}
+	err = json.Unmarshal(response.Body(), &project)
+	if err != nil {
+		return nil, err
+	}
+
+	project.Branch, err = findDefaultBranch(client, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if project.Branch == nil {
+		return project, nil
+	}
+	branchURL := fmt.Sprintf("/projects/%s/branches/%s", projectID, project.Branch.ID)
+
+	response, err = client.R().Get(branchURL + "/endpoints")
+	if err != nil {
+		return nil, err
+	}
+	if response.IsError() {
+		return nil, fmt.Errorf("failed to list branch endpoints with a status code: %s", response.Status())
+	}
+	endpoints := struct {
+		Endpoints []endpointResourceJSON `json:"endpoints"`
+	}{}
+	err = json.Unmarshal(response.Body(), &endpoints)
+	if err != nil {
+		return nil, err
+	}
+	project.Endpoints = endpoints.Endpoints
+
+	response, err = client.R().Get(branchURL + "/databases")
+	if err != nil {
+		return nil, err
+	}
+	if response.IsError() {
+		return nil, fmt.Errorf("failed to list branch databases with a status code: %s", response.Status())
+	}
+	databases := struct {
+		Databases []databaseResourceJSON `json:"databases"`
+	}{}
+	err = json.Unmarshal(response.Body(), &databases)
+	if err != nil {
+		return nil, err
+	}
+	project.Databases = databases.Databases
+
+	response, err = client.R().Get(branchURL + "/roles")
+	if err != nil {
+		return nil, err
+	}
+	if response.IsError() {
+		return nil, fmt.Errorf("failed to list branch roles with a status code: %s", response.Status())
+	}
+	roles := struct {
+		Roles []roleResourceJSON `json:"roles"`
+	}{}
+	err = json.Unmarshal(response.Body(), &roles)
+	if err != nil {
+		return nil, err
+	}
+	// Only the owner of the first database is revealed, as its password is in
+	// the connection uri. The other passwords are not returned on creation
+	// either, and revealing them would cost a request per role.
+	owner := ""
+	if len(project.Databases) != 0 {
+		owner = project.Databases[0].OwnerName
+	}
+	for i, v := range roles.Roles {
+		if password, ok := passwords[v.Name]; ok {
+			roles.Roles[i].Password = password
+			continue
+		}
+		if v.Protected || v.Name != owner {
+			continue
+		}
+		roles.Roles[i].Password, err = revealRolePassword(client, projectID, project.Branch.ID, v.Name)
+		if err != nil {
+			return nil, err
+		}
+	}
+	project.Roles = roles.Roles
+
+	// Creation returns the connection uri of the first database and its owner.
+	project.ConnectionUris = nil
+	if len(project.Databases) != 0 && len(project.Endpoints) != 0 {
+		uri, err := getConnectionURI(client, projectID, project.Branch.ID, "", project.Databases[0].Name, project.Databases[0].OwnerName, false)
+		if err != nil {
+			return nil, err
+		}
+		project.ConnectionUris = []projectConnUrisJSON{{ConnectionURI: uri}}
+	}
+	return project, nil
 }
 
 type updateProject struct {
-	Name                     string `json:"name"`
-	Autoscaling_limit_min_cu int64  `json:"autoscaling_limit_min_cu,omitempty"`
-	Autoscaling_limit_max_cu int64  `json:"autoscaling_limit_max_cu,omitempty"`
+	Name                     string               `json:"name"`
+	Settings                 *projectSettingsJSON `json:"settings,omitempty"`
+	Autoscaling_limit_min_cu int64                `json:"autoscaling_limit_min_cu,omitempty"`
+	Autoscaling_limit_max_cu int64                `json:"autoscaling_limit_max_cu,omitempty"`
+}
+
+// transferProject moves a project to another organization. An empty
+// sourceOrgID means the project belongs to the personal account.
+func transferProject(client *resty.Client, projectID, sourceOrgID, destinationOrgID string) error {
+	content := struct {
+		DestinationOrgID string   `json:"destination_org_id"`
+		ProjectIDs       []string `json:"project_ids"`
+	}{
+		DestinationOrgID: destinationOrgID,
+		ProjectIDs:       []string{projectID},
+	}
+	url := "/users/me/projects/transfer"
+	if sourceOrgID != "" {
+		url = fmt.Sprintf("/organizations/%s/projects/transfer", sourceOrgID)
+	}
+	response, err := client.R().
+		SetBody(content).
+		Post(url)
+	if err != nil {
+		return err
+	}
+	if response.IsError() {
+		return fmt.Errorf("failed to transfer project with a status code: %s", response.Status())
+	}
+	return nil
 }
 
 // Update implements resource.Resource
@@ -278,17 +553,31 @@ func (r projectResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	var ID types.String
+	var ID, orgID types.String
 	diags = req.State.GetAttribute(ctx, path.Root("id"), &ID)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	diags = req.State.GetAttribute(ctx, path.Root("org_id"), &orgID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.OrgID.IsUnknown() && !data.OrgID.IsNull() && !data.OrgID.Equal(orgID) {
+		err := transferProject(r.client, ID.ValueString(), orgID.ValueString(), data.OrgID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to transfer project", err.Error())
+			return
+		}
+	}
 
 	u := updateProject{
 		Autoscaling_limit_min_cu: data.AutoscalingLimitMinCu.ValueInt64(),
 		Autoscaling_limit_max_cu: data.AutoscalingLimitMaxCu.ValueInt64(),
 		Name:                     data.Name.ValueString(),
+		Settings:                 data.toProjectSettingsJSON(),
 	}
 	content := struct {
 		Project updateProject `json:"project"`
@@ -303,10 +592,20 @@ func (r projectResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	project := &projectResourceJSON{}
-	err := json.Unmarshal(response.Body(), &project)
+	var state projectResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	passwords, diags := state.rolePasswords(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	project, err := readProject(r.client, ID.ValueString(), passwords)
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to unmarshal response", err.Error())
+		resp.Diagnostics.AddError("Failed to read project resource", err.Error())
 		return
 	}
 	plan, diags := project.ToProjectResourceModel(ctx)
@@ -316,8 +615,45 @@ func (r projectResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.State, resp.Identity)...)
+}
+
+// ModifyPlan validates the region and guards enable_logical_replication, which
+// Neon does not allow to turn off once enabled.
+func (r projectResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(r.regions.validatePlan(ctx, req.Plan)...)
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var plan, state types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("enable_logical_replication"), &plan)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("enable_logical_replication"), &state)...)
+	}
+	if resp.Diagnostics.HasError() || plan.IsNull() || plan.IsUnknown() {
+		return
+	}
+	if state.ValueBool() && !plan.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("enable_logical_replication"),
+			"Cannot disable logical replication",
+			"Logical replication cannot be disabled once it is enabled, the project has to be recreated",
+		)
+		return
+	}
+	if !state.ValueBool() && plan.ValueBool() && !req.State.Raw.IsNull() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("enable_logical_replication"),
+			"Enabling logical replication restarts the computes",
+			"All computes of the project are restarted, active connections are dropped. This change cannot be undone",
+		)
+	}
+}
+
+func (r projectResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identitySchema("id")
 }
 
 func (r projectResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importState(ctx, req, resp, "project", "id")
 }
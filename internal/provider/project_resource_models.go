@@ -2,8 +2,14 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	datasourceschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -17,20 +23,37 @@ type projectConnUrisJSON struct {
 type endpointSettingsJSON struct {
 	PgSettings map[string]string `json:"pg_settings"`
 }
+type projectSettingsJSON struct {
+	BlockPublicConnections   *bool `json:"block_public_connections,omitempty"`
+	EnableLogicalReplication *bool `json:"enable_logical_replication,omitempty"`
+}
+type defaultEndpointSettingsJSON struct {
+	AutoscalingLimitMinCu float64 `json:"autoscaling_limit_min_cu"`
+	AutoscalingLimitMaxCu float64 `json:"autoscaling_limit_max_cu"`
+}
+
+// wholeComputeUnits rounds up a limit of default_endpoint_settings, which may
+// be fractional like 0.25, to the whole compute units of the schema.
+func wholeComputeUnits(cu float64) int64 {
+	return int64(math.Ceil(cu))
+}
+
 type innerProjectResourceJSON struct {
-	MaintenanceStartsAt   string                `json:"maintenance_starts_at"`
-	ID                    string                `json:"id"`
-	PlatformID            string                `json:"platform_id"`
-	RegionID              string                `json:"region_id"`
-	Name                  string                `json:"name"`
-	Provisioner           string                `json:"provisioner"`
-	Settings              *endpointSettingsJSON `json:"settings"`
-	PgVersion             int64                 `json:"pg_version"`
-	AutoscalingLimitMinCu int64                 `json:"autoscaling_limit_min_cu"`
-	AutoscalingLimitMaxCu int64                 `json:"autoscaling_limit_max_cu"`
-	LastActive            string                `json:"last_active"`
-	CreatedAt             string                `json:"created_at"`
-	UpdatedAt             string                `json:"updated_at"`
+	MaintenanceStartsAt     string                       `json:"maintenance_starts_at"`
+	ID                      string                       `json:"id"`
+	PlatformID              string                       `json:"platform_id"`
+	RegionID                string                       `json:"region_id"`
+	OrgID                   string                       `json:"org_id,omitempty"`
+	Name                    string                       `json:"name"`
+	Provisioner             string                       `json:"provisioner"`
+	Settings                *projectSettingsJSON         `json:"settings,omitempty"`
+	DefaultEndpointSettings *defaultEndpointSettingsJSON `json:"default_endpoint_settings,omitempty"`
+	PgVersion               int64                        `json:"pg_version"`
+	AutoscalingLimitMinCu   int64                        `json:"autoscaling_limit_min_cu"`
+	AutoscalingLimitMaxCu   int64                        `json:"autoscaling_limit_max_cu"`
+	LastActive              string                       `json:"last_active"`
+	CreatedAt               string                       `json:"created_at"`
+	UpdatedAt               string                       `json:"updated_at"`
 }
 type projectResourceJSON struct {
 	Project        innerProjectResourceJSON `json:"project"`
@@ -42,27 +65,83 @@ type projectResourceJSON struct {
 }
 
 type projectConnUrisModel struct {
-	ConnectionURI types.String `tfsdk:"connection_uri"`
+	ConnectionURI       types.String `tfsdk:"connection_uri"`
+	Host                types.String `tfsdk:"host"`
+	Port                types.Int64  `tfsdk:"port"`
+	Database            types.String `tfsdk:"database"`
+	User                types.String `tfsdk:"user"`
+	Password            types.String `tfsdk:"password"`
+	PooledHost          types.String `tfsdk:"pooled_host"`
+	PooledConnectionURI types.String `tfsdk:"pooled_connection_uri"`
+}
+
+// poolerHost returns the hostname of the connection pooler in front of an
+// endpoint host, e.g. ep-foo-123-pooler.us-east-2.aws.neon.tech.
+func poolerHost(host string) string {
+	if host == "" {
+		return ""
+	}
+	label, domain, found := strings.Cut(host, ".")
+	if !found {
+		return label + "-pooler"
+	}
+	return label + "-pooler." + domain
+}
+
+// toProjectConnUrisModel splits a postgres connection uri into its parts.
+// Errors never include the uri, since it carries the role password.
+func (in *projectConnUrisJSON) toProjectConnUrisModel() (*projectConnUrisModel, error) {
+	u, err := url.Parse(in.ConnectionURI)
+	if err != nil || u.Hostname() == "" {
+		return nil, errors.New("cannot parse connection uri")
+	}
+	port := int64(5432)
+	if u.Port() != "" {
+		port, err = strconv.ParseInt(u.Port(), 10, 64)
+		if err != nil {
+			return nil, errors.New("cannot parse connection uri port")
+		}
+	}
+	password, _ := u.User.Password()
+
+	pooled := *u
+	pooled.Host = poolerHost(u.Hostname())
+	if u.Port() != "" {
+		pooled.Host += ":" + u.Port()
+	}
+	return &projectConnUrisModel{
+		ConnectionURI:       types.StringValue(in.ConnectionURI),
+		Host:                types.StringValue(u.Hostname()),
+		Port:                types.Int64Value(port),
+		Database:            types.StringValue(strings.TrimPrefix(u.Path, "/")),
+		User:                types.StringValue(u.User.Username()),
+		Password:            types.StringValue(password),
+		PooledHost:          types.StringValue(poolerHost(u.Hostname())),
+		PooledConnectionURI: types.StringValue(pooled.String()),
+	}, nil
 }
 
 type projectResourceModel struct {
-	MaintenanceStartsAt   types.String `tfsdk:"maintenance_starts_at"`
-	ID                    types.String `tfsdk:"id"`
-	PlatformID            types.String `tfsdk:"platform_id"`
-	RegionID              types.String `tfsdk:"region_id"`
-	Name                  types.String `tfsdk:"name"`
-	Provisioner           types.String `tfsdk:"engine"`
-	PgVersion             types.Int64  `tfsdk:"pg_version"`
-	AutoscalingLimitMinCu types.Int64  `tfsdk:"autoscaling_limit_min_cu"`
-	AutoscalingLimitMaxCu types.Int64  `tfsdk:"autoscaling_limit_max_cu"`
-	LastActive            types.String `tfsdk:"last_active"`
-	CreatedAt             types.String `tfsdk:"created_at"`
-	UpdatedAt             types.String `tfsdk:"updated_at"`
-	ConnectionUris        types.List   `tfsdk:"connection_uris"`
-	Roles                 types.List   `tfsdk:"roles"`
-	Databases             types.List   `tfsdk:"databases"`
-	Branch                types.Object `tfsdk:"branch"`
-	Endpoints             types.List   `tfsdk:"endpoints"`
+	MaintenanceStartsAt      types.String `tfsdk:"maintenance_starts_at"`
+	ID                       types.String `tfsdk:"id"`
+	PlatformID               types.String `tfsdk:"platform_id"`
+	RegionID                 types.String `tfsdk:"region_id"`
+	OrgID                    types.String `tfsdk:"org_id"`
+	BlockPublicConnections   types.Bool   `tfsdk:"block_public_connections"`
+	EnableLogicalReplication types.Bool   `tfsdk:"enable_logical_replication"`
+	Name                     types.String `tfsdk:"name"`
+	Provisioner              types.String `tfsdk:"engine"`
+	PgVersion                types.Int64  `tfsdk:"pg_version"`
+	AutoscalingLimitMinCu    types.Int64  `tfsdk:"autoscaling_limit_min_cu"`
+	AutoscalingLimitMaxCu    types.Int64  `tfsdk:"autoscaling_limit_max_cu"`
+	LastActive               types.String `tfsdk:"last_active"`
+	CreatedAt                types.String `tfsdk:"created_at"`
+	UpdatedAt                types.String `tfsdk:"updated_at"`
+	ConnectionUris           types.List   `tfsdk:"connection_uris"`
+	Roles                    types.List   `tfsdk:"roles"`
+	Databases                types.List   `tfsdk:"databases"`
+	Branch                   types.Object `tfsdk:"branch"`
+	Endpoints                types.List   `tfsdk:"endpoints"`
 }
 
 func newProjectResourceModel() *projectResourceModel {
@@ -81,11 +160,28 @@ func (p *projectResourceJSON) ToProjectResourceModel(ctx context.Context) (*proj
 	m.ID = types.StringValue(p.Project.ID)
 	m.PlatformID = types.StringValue(p.Project.PlatformID)
 	m.RegionID = types.StringValue(p.Project.RegionID)
+	m.OrgID = types.StringNull()
+	if p.Project.OrgID != "" {
+		m.OrgID = types.StringValue(p.Project.OrgID)
+	}
+	m.BlockPublicConnections = types.BoolValue(false)
+	m.EnableLogicalReplication = types.BoolValue(false)
+	if p.Project.Settings != nil {
+		m.BlockPublicConnections = types.BoolValue(p.Project.Settings.BlockPublicConnections != nil && *p.Project.Settings.BlockPublicConnections)
+		m.EnableLogicalReplication = types.BoolValue(p.Project.Settings.EnableLogicalReplication != nil && *p.Project.Settings.EnableLogicalReplication)
+	}
 	m.Name = types.StringValue(p.Project.Name)
 	m.Provisioner = types.StringValue(p.Project.Provisioner)
 	m.PgVersion = types.Int64Value(p.Project.PgVersion)
 	m.AutoscalingLimitMinCu = types.Int64Value(p.Project.AutoscalingLimitMinCu)
 	m.AutoscalingLimitMaxCu = types.Int64Value(p.Project.AutoscalingLimitMaxCu)
+	// Newer projects only report the limits in default_endpoint_settings.
+	if p.Project.AutoscalingLimitMinCu == 0 && p.Project.DefaultEndpointSettings != nil {
+		m.AutoscalingLimitMinCu = types.Int64Value(wholeComputeUnits(p.Project.DefaultEndpointSettings.AutoscalingLimitMinCu))
+	}
+	if p.Project.AutoscalingLimitMaxCu == 0 && p.Project.DefaultEndpointSettings != nil {
+		m.AutoscalingLimitMaxCu = types.Int64Value(wholeComputeUnits(p.Project.DefaultEndpointSettings.AutoscalingLimitMaxCu))
+	}
 	m.LastActive = types.StringValue(p.Project.LastActive)
 	m.CreatedAt = types.StringValue(p.Project.CreatedAt)
 	m.UpdatedAt = types.StringValue(p.Project.UpdatedAt)
@@ -104,9 +200,13 @@ func (p *projectResourceJSON) ToProjectResourceModel(ctx context.Context) (*proj
 	if len(p.ConnectionUris) != 0 {
 		c := []projectConnUrisModel{}
 		for _, v := range p.ConnectionUris {
-			c = append(c, projectConnUrisModel{
-				ConnectionURI: types.StringValue(v.ConnectionURI),
-			})
+			aux, err := v.toProjectConnUrisModel()
+			if err != nil {
+				var diags diag.Diagnostics
+				diags.AddError("Failed to parse connection uri", err.Error())
+				return nil, diags
+			}
+			c = append(c, *aux)
 		}
 		aux, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: typeFromAttrs(connectionUriResourceAttr())}, c)
 		if diags.HasError() {
@@ -158,6 +258,41 @@ func (p *projectResourceJSON) ToProjectResourceModel(ctx context.Context) (*proj
 	}
 	return m, nil
 }
+
+// rolePasswords returns the passwords of the roles in state by role name.
+func (m *projectResourceModel) rolePasswords(ctx context.Context) (map[string]string, diag.Diagnostics) {
+	passwords := map[string]string{}
+	if m.Roles.IsNull() || m.Roles.IsUnknown() {
+		return passwords, nil
+	}
+	for _, v := range m.Roles.Elements() {
+		role := roleResourceModel{}
+		diags := v.(types.Object).As(ctx, &role, basetypes.ObjectAsOptions{})
+		if diags.HasError() {
+			return nil, diags
+		}
+		if !role.Password.IsNull() && !role.Password.IsUnknown() {
+			passwords[role.Name.ValueString()] = role.Password.ValueString()
+		}
+	}
+	return passwords, nil
+}
+
+// toProjectSettingsJSON returns the settings to send to the api, or nil when
+// none are set in the plan.
+func (m *projectResourceModel) toProjectSettingsJSON() *projectSettingsJSON {
+	settings := &projectSettingsJSON{}
+	if !m.BlockPublicConnections.IsNull() && !m.BlockPublicConnections.IsUnknown() {
+		settings.BlockPublicConnections = m.BlockPublicConnections.ValueBoolPointer()
+	}
+	if !m.EnableLogicalReplication.IsNull() && !m.EnableLogicalReplication.IsUnknown() {
+		settings.EnableLogicalReplication = m.EnableLogicalReplication.ValueBoolPointer()
+	}
+	if settings.BlockPublicConnections == nil && settings.EnableLogicalReplication == nil {
+		return nil
+	}
+	return settings
+}
 func typeFromAttrs(in map[string]schema.Attribute) map[string]attr.Type {
 	out := map[string]attr.Type{}
 	for k, v := range in {
@@ -165,6 +300,13 @@ func typeFromAttrs(in map[string]schema.Attribute) map[string]attr.Type {
 	}
 	return out
 }
+func typeFromDataAttrs(in map[string]datasourceschema.Attribute) map[string]attr.Type {
+	out := map[string]attr.Type{}
+	for k, v := range in {
+		out[k] = v.GetType()
+	}
+	return out
+}
 func (m *projectResourceModel) ToProjectResourceJSON(ctx context.Context) (*projectResourceJSON, diag.Diagnostics) {
 	p := &projectResourceJSON{
 		Project: innerProjectResourceJSON{
@@ -172,6 +314,8 @@ func (m *projectResourceModel) ToProjectResourceJSON(ctx context.Context) (*proj
 			ID:                    m.ID.ValueString(),
 			PlatformID:            m.PlatformID.ValueString(),
 			RegionID:              m.RegionID.ValueString(),
+			OrgID:                 m.OrgID.ValueString(),
+			Settings:              m.toProjectSettingsJSON(),
 			Name:                  m.Name.ValueString(),
 			Provisioner:           m.Provisioner.ValueString(),
 			PgVersion:             m.PgVersion.ValueInt64(),
@@ -184,7 +328,8 @@ func (m *projectResourceModel) ToProjectResourceJSON(ctx context.Context) (*proj
 	}
 	if !m.Branch.IsNull() {
 		v := BranchResourceModel{
-			Endpoints: types.ListNull(types.ObjectType{AttrTypes: typeFromAttrs(branchResourceEndpointAttr())}), //not necessary?
+			Endpoints:       types.MapNull(types.ObjectType{AttrTypes: typeFromAttrs(branchResourceEndpointAttr())}), //not necessary?
+			EndpointDetails: types.ListNull(types.ObjectType{AttrTypes: typeFromAttrs(branchEndpointDetailsAttr())}),
 		}
 		diags := m.Branch.As(ctx, &v, basetypes.ObjectAsOptions{})
 		if diags.HasError() {
@@ -0,0 +1,191 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &projectsDataSource{}
+
+// projectsPageLimit is the maximum page size accepted by the /projects list endpoint.
+const projectsPageLimit = 400
+
+type projectsDataSource struct {
+	client *resty.Client
+}
+
+type projectsDataModel struct {
+	ID        types.String `tfsdk:"id"`
+	Search    types.String `tfsdk:"search"`
+	OrgID     types.String `tfsdk:"org_id"`
+	RegionID  types.String `tfsdk:"region_id"`
+	PgVersion types.Int64  `tfsdk:"pg_version"`
+	Projects  types.List   `tfsdk:"projects"`
+}
+
+type projectsPaginationJSON struct {
+	Cursor string `json:"cursor"`
+}
+
+type projectsDataJSON struct {
+	Projects   []projectDataJSON       `json:"projects"`
+	Pagination *projectsPaginationJSON `json:"pagination"`
+}
+
+func projectsDataProjectAttr() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			MarkdownDescription: "project id",
+			Computed:            true,
+		},
+		"platform_id": schema.StringAttribute{
+			MarkdownDescription: "platform id",
+			Computed:            true,
+		},
+		"region_id": schema.StringAttribute{
+			MarkdownDescription: "region id",
+			Computed:            true,
+		},
+		"name": schema.StringAttribute{
+			MarkdownDescription: "project name",
+			Computed:            true,
+		},
+		"pg_version": schema.Int64Attribute{
+			MarkdownDescription: "postgres version",
+			Computed:            true,
+		},
+		"last_active": schema.StringAttribute{
+			MarkdownDescription: "last active",
+			Computed:            true,
+		},
+		"created_at": schema.StringAttribute{
+			MarkdownDescription: "created at",
+			Computed:            true,
+		},
+		"updated_at": schema.StringAttribute{
+			MarkdownDescription: "updated at",
+			Computed:            true,
+		},
+	}
+}
+
+// listProjects walks every page of the /projects endpoint and returns the
+// project summaries matching search and orgID, both of which may be empty.
+func listProjects(client *resty.Client, search, orgID string) ([]projectDataJSON, error) {
+	projects := []projectDataJSON{}
+	cursor := ""
+	for {
+		request := client.R().SetQueryParam("limit", strconv.Itoa(projectsPageLimit))
+		if cursor != "" {
+			request.SetQueryParam("cursor", cursor)
+		}
+		if search != "" {
+			request.SetQueryParam("search", search)
+		}
+		if orgID != "" {
+			request.SetQueryParam("org_id", orgID)
+		}
+		response, err := request.Get("/projects")
+		if err != nil {
+			return nil, err
+		}
+		if response.IsError() {
+			return nil, fmt.Errorf("failed to list projects with a status code: %s", response.Status())
+		}
+		page := projectsDataJSON{}
+		err = json.Unmarshal(response.Body(), &page)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, page.Projects...)
+		if len(page.Projects) < projectsPageLimit || page.Pagination == nil || page.Pagination.Cursor == "" || page.Pagination.Cursor == cursor {
+			return projects, nil
+		}
+		cursor = page.Pagination.Cursor
+	}
+}
+
+// Metadata implements datasource.DataSource
+func (*projectsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_projects"
+}
+
+// Read implements datasource.DataSource
+func (d *projectsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data projectsDataModel
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projects, err := listProjects(d.client, data.Search.ValueString(), data.OrgID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to list projects", err.Error())
+		return
+	}
+
+	p := []projectDataModel{}
+	for _, v := range projects {
+		if !data.RegionID.IsNull() && v.RegionID != data.RegionID.ValueString() {
+			continue
+		}
+		if !data.PgVersion.IsNull() && v.PgVersion != data.PgVersion.ValueInt64() {
+			continue
+		}
+		p = append(p, *v.ToProjectDataModel())
+	}
+	aux, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: typeFromDataAttrs(projectsDataProjectAttr())}, p)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Projects = aux
+	data.ID = types.StringValue("projects")
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Schema implements datasource.DataSource
+func (*projectsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "placeholder identifier",
+				Computed:            true,
+			},
+			"search": schema.StringAttribute{
+				MarkdownDescription: "search projects by name or id",
+				Optional:            true,
+			},
+			"org_id": schema.StringAttribute{
+				MarkdownDescription: "only list projects of this organization",
+				Optional:            true,
+			},
+			"region_id": schema.StringAttribute{
+				MarkdownDescription: "only list projects in this region",
+				Optional:            true,
+			},
+			"pg_version": schema.Int64Attribute{
+				MarkdownDescription: "only list projects with this postgres version",
+				Optional:            true,
+			},
+			"projects": schema.ListNestedAttribute{
+				MarkdownDescription: "project summaries",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: projectsDataProjectAttr(),
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestProjectsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testProjectsDataSource(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.neon_projects.test", "projects.#", "1"),
+					resource.TestCheckResourceAttr("data.neon_projects.test", "projects.0.name", "name_projects_search"),
+					resource.TestCheckResourceAttrPair("data.neon_projects.test", "projects.0.id", "neon_project.test", "id"),
+					resource.TestCheckResourceAttr("data.neon_projects.none", "projects.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testProjectsDataSource() string {
+	return `
+resource "neon_project" "test" {
+	name = "name_projects_search"
+	region_id = "aws-us-east-2"
+	pg_version = 15
+}
+
+data "neon_projects" "test" {
+	search = neon_project.test.name
+	region_id = "aws-us-east-2"
+	pg_version = 15
+}
+
+data "neon_projects" "none" {
+	search = neon_project.test.name
+	pg_version = 14
+}
+`
+}
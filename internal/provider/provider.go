@@ -7,18 +7,28 @@ import (
 
 	"github.com/go-resty/resty/v2"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure ScaffoldingProvider satisfies various provider interfaces.
 var _ provider.Provider = &neon{}
+var _ provider.ProviderWithEphemeralResources = &neon{}
 
 // neon defines the provider implementation.
 type neon struct {
-	version string
-	client  *resty.Client
+	version      string
+	client       *resty.Client
+	regions      *regionCache
+	defaultOrgID string
+}
+
+type neonProviderModel struct {
+	ValidateRegions types.Bool   `tfsdk:"validate_regions"`
+	DefaultOrgID    types.String `tfsdk:"default_org_id"`
 }
 
 func (p *neon) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -27,10 +37,28 @@ func (p *neon) Metadata(ctx context.Context, req provider.MetadataRequest, resp
 }
 
 func (p *neon) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
-	resp.Schema = schema.Schema{}
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"validate_regions": schema.BoolAttribute{
+				MarkdownDescription: "check region_id against the regions supported by Neon at plan time",
+				Optional:            true,
+			},
+			"default_org_id": schema.StringAttribute{
+				MarkdownDescription: "organization new projects are created in when they don't set org_id",
+				Optional:            true,
+			},
+		},
+	}
 }
 
 func (provider *neon) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	var data neonProviderModel
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var key string
 	var ok bool
 	if key, ok = os.LookupEnv("NEON_API_KEY"); !ok {
@@ -40,7 +68,18 @@ func (provider *neon) Configure(ctx context.Context, req provider.ConfigureReque
 		)
 		return
 	}
-	provider.client = resty.New().
+	provider.client = NewClient(key)
+
+	if data.ValidateRegions.ValueBool() {
+		provider.regions = &regionCache{client: provider.client}
+	}
+	provider.defaultOrgID = data.DefaultOrgID.ValueString()
+}
+
+// NewClient returns the Neon API client used by the provider, authenticated
+// with key and retrying while the project is locked by another operation.
+func NewClient(key string) *resty.Client {
+	return resty.New().
 		SetBaseURL("https://console.neon.tech/api/v2").
 		SetAuthToken(key).
 		SetHeader("Content-Type", "application/json").
@@ -53,7 +92,9 @@ func (provider *neon) Configure(ctx context.Context, req provider.ConfigureReque
 	//SetError(fmt.Errorf("generic error"))
 }
 
-func (p neon) Resources(ctx context.Context) []func() resource.Resource {
+// Resources and DataSources use a pointer receiver so the factories read the
+// client set by Configure instead of a copy taken before it ran.
+func (p *neon) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		func() resource.Resource {
 			return &branchResource{
@@ -62,11 +103,19 @@ func (p neon) Resources(ctx context.Context) []func() resource.Resource {
 		},
 		func() resource.Resource {
 			return &projectResource{
-				client: p.client,
+				client:       p.client,
+				regions:      p.regions,
+				defaultOrgID: p.defaultOrgID,
 			}
 		},
 		func() resource.Resource {
 			return &endpointResource{
+				client:  p.client,
+				regions: p.regions,
+			}
+		},
+		func() resource.Resource {
+			return &readReplicaResource{
 				client: p.client,
 			}
 		},
@@ -80,10 +129,51 @@ func (p neon) Resources(ctx context.Context) []func() resource.Resource {
 				client: p.client,
 			}
 		},
+		func() resource.Resource {
+			return &apiKeyResource{
+				client: p.client,
+			}
+		},
+		func() resource.Resource {
+			return &projectPermissionResource{
+				client: p.client,
+			}
+		},
+		func() resource.Resource {
+			return &vpcEndpointResource{
+				client:       p.client,
+				defaultOrgID: p.defaultOrgID,
+			}
+		},
+		func() resource.Resource {
+			return &vpcEndpointRestrictionResource{
+				client: p.client,
+			}
+		},
+		func() resource.Resource {
+			return &publicationResource{
+				client: p.client,
+			}
+		},
+		func() resource.Resource {
+			return &replicationSlotResource{
+				client: p.client,
+			}
+		},
+		func() resource.Resource {
+			return &grantResource{
+				client: p.client,
+			}
+		},
+		func() resource.Resource {
+			return &extensionResource{
+				client: p.client,
+			}
+		},
 	}
 }
 
-func (p neon) DataSources(ctx context.Context) []func() datasource.DataSource {
+func (p *neon) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		func() datasource.DataSource {
 			return &projectDataSource{
@@ -105,6 +195,46 @@ func (p neon) DataSources(ctx context.Context) []func() datasource.DataSource {
 				client: p.client,
 			}
 		},
+		func() datasource.DataSource {
+			return &projectsDataSource{
+				client: p.client,
+			}
+		},
+		func() datasource.DataSource {
+			return &regionsDataSource{
+				client: p.client,
+			}
+		},
+		func() datasource.DataSource {
+			return &connectionURIDataSource{
+				client: p.client,
+			}
+		},
+		func() datasource.DataSource {
+			return &apiKeysDataSource{
+				client: p.client,
+			}
+		},
+		func() datasource.DataSource {
+			return &organizationDataSource{
+				client: p.client,
+			}
+		},
+		func() datasource.DataSource {
+			return &projectPermissionsDataSource{
+				client: p.client,
+			}
+		},
+	}
+}
+
+func (p *neon) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		func() ephemeral.EphemeralResource {
+			return &roleCredentialsEphemeralResource{
+				client: p.client,
+			}
+		},
 	}
 }
 
@@ -1,11 +1,20 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"os"
+	"os/exec"
 	"testing"
 
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/jackc/pgx/v5"
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
@@ -21,3 +30,111 @@ func testAccPreCheck(t *testing.T) {
 		t.Fatal("NEON_API_KEY not present")
 	}
 }
+
+// testAccPreCheckOrganization skips tests that need an organization the api
+// key can create projects in.
+func testAccPreCheckOrganization(t *testing.T) {
+	testAccPreCheck(t)
+	if _, ok := os.LookupEnv("NEON_ORG_ID"); !ok {
+		t.Skip("NEON_ORG_ID not present")
+	}
+}
+
+// testAccPreCheckGrantee skips tests that need a second neon user to share
+// projects with.
+func testAccPreCheckGrantee(t *testing.T) {
+	testAccPreCheck(t)
+	if _, ok := os.LookupEnv("NEON_GRANTEE_EMAIL"); !ok {
+		t.Skip("NEON_GRANTEE_EMAIL not present")
+	}
+}
+
+// testAccPreCheckVpcEndpoint skips tests that need an aws vpc endpoint in
+// aws-us-east-2 to register with the organization.
+func testAccPreCheckVpcEndpoint(t *testing.T) {
+	testAccPreCheckOrganization(t)
+	if _, ok := os.LookupEnv("NEON_VPC_ENDPOINT_ID"); !ok {
+		t.Skip("NEON_VPC_ENDPOINT_ID not present")
+	}
+}
+
+// testAccPreCheckPostgres points the sql backed resources to the local
+// postgres in NEON_TEST_POSTGRES_DSN instead of a neon endpoint, so
+// project_id, branch_id and database_name are not looked up.
+func testAccPreCheckPostgres(t *testing.T, setup ...string) {
+	dsn, ok := os.LookupEnv("NEON_TEST_POSTGRES_DSN")
+	if !ok {
+		t.Skip("NEON_TEST_POSTGRES_DSN not present")
+	}
+	if _, ok := os.LookupEnv("NEON_API_KEY"); !ok {
+		t.Setenv("NEON_API_KEY", "unused")
+	}
+	conn, err := pgx.Connect(context.Background(), dsn)
+	if err != nil {
+		t.Fatalf("cannot connect to NEON_TEST_POSTGRES_DSN: %s", err)
+	}
+	defer conn.Close(context.Background())
+	for _, v := range setup {
+		if _, err := conn.Exec(context.Background(), v); err != nil {
+			t.Fatalf("cannot set up test database: %s", err)
+		}
+	}
+	open := openDatabase
+	openDatabase = func(ctx context.Context, client *resty.Client, projectID, branchID, databaseName string) (*pgx.Conn, error) {
+		return pgx.Connect(ctx, dsn)
+	}
+	t.Cleanup(func() { openDatabase = open })
+}
+
+// testAccPreCheckEphemeral skips tests using ephemeral resources, which need
+// Terraform 1.10 or later.
+func testAccPreCheckEphemeral(t *testing.T) {
+	testAccPreCheck(t)
+	terraform := os.Getenv("TF_ACC_TERRAFORM_PATH")
+	if terraform == "" {
+		terraform = "terraform"
+	}
+	out, err := exec.Command(terraform, "version", "-json").Output()
+	if err != nil {
+		t.Skipf("cannot get terraform version: %s", err)
+	}
+	v := struct {
+		Version string `json:"terraform_version"`
+	}{}
+	if err := json.Unmarshal(out, &v); err != nil {
+		t.Skipf("cannot get terraform version: %s", err)
+	}
+	if version.Must(version.NewVersion(v.Version)).LessThan(version.Must(version.NewVersion("1.10.0"))) {
+		t.Skipf("ephemeral resources need terraform 1.10 or later, found %s", v.Version)
+	}
+}
+
+// testAccCheckResourceID stores the id of the resource name, to tell with
+// testAccCheckResourceReplaced whether a later step replaced it.
+func testAccCheckResourceID(name string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("cannot find %s", name)
+		}
+		*id = rs.Primary.ID
+		return nil
+	}
+}
+
+func testAccCheckResourceReplaced(name string, id *string, replaced bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("cannot find %s", name)
+		}
+		if replaced && rs.Primary.ID == *id {
+			return fmt.Errorf("%s was updated in place, want it replaced", name)
+		}
+		if !replaced && rs.Primary.ID != *id {
+			return fmt.Errorf("%s was replaced, want it updated in place", name)
+		}
+		*id = rs.Primary.ID
+		return nil
+	}
+}
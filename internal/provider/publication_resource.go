@@ -0,0 +1,250 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jackc/pgx/v5"
+)
+
+type publicationResource struct {
+	client *resty.Client
+}
+type publicationResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	ProjectID    types.String `tfsdk:"project_id"`
+	BranchID     types.String `tfsdk:"branch_id"`
+	DatabaseName types.String `tfsdk:"database_name"`
+	Name         types.String `tfsdk:"name"`
+	Tables       types.Set    `tfsdk:"tables"`
+}
+
+var _ resource.Resource = publicationResource{}
+var _ resource.ResourceWithUpgradeState = publicationResource{}
+var _ resource.ResourceWithImportState = publicationResource{}
+var _ resource.ResourceWithIdentity = publicationResource{}
+
+// quoteTable quotes a schema qualified table name, e.g. public.users.
+func quoteTable(table string) string {
+	schemaName, tableName, _ := strings.Cut(table, ".")
+	return pgx.Identifier{schemaName, tableName}.Sanitize()
+}
+
+func quoteTables(tables []string) string {
+	quoted := []string{}
+	for _, v := range tables {
+		quoted = append(quoted, quoteTable(v))
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// readPublicationTables returns the tables of a publication, or nil when the
+// publication does not exist.
+func readPublicationTables(ctx context.Context, conn *pgx.Conn, name string) ([]string, error) {
+	var exists bool
+	err := conn.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM pg_publication WHERE pubname = $1)", name).Scan(&exists)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, nil
+	}
+	rows, err := conn.Query(ctx, "SELECT schemaname || '.' || tablename FROM pg_publication_tables WHERE pubname = $1", name)
+	if err != nil {
+		return nil, err
+	}
+	tables, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(tables)
+	return tables, nil
+}
+
+func (r publicationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_publication"
+}
+
+func (r publicationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version:             1,
+		MarkdownDescription: "Postgres publication for logical replication, managed by connecting to the branch read_write endpoint as the database owner. The project needs enable_logical_replication",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "publication identifier",
+				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "project id",
+				Required:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"branch_id": schema.StringAttribute{
+				MarkdownDescription: "branch id",
+				Required:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"database_name": schema.StringAttribute{
+				MarkdownDescription: "database name",
+				Required:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "publication name",
+				Required:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"tables": schema.SetAttribute{
+				MarkdownDescription: "schema qualified tables to publish, e.g. public.users",
+				Required:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.RegexMatches(tableNameRegex, "must be schema qualified, e.g. public.users")),
+				},
+			},
+		},
+	}
+}
+
+func (r publicationResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: upgradeFromV0(r),
+	}
+}
+
+func (r publicationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data publicationResourceModel
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tables := []string{}
+	resp.Diagnostics.Append(data.Tables.ElementsAs(ctx, &tables, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	conn, err := openDatabase(ctx, r.client, data.ProjectID.ValueString(), data.BranchID.ValueString(), data.DatabaseName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to connect to database", err.Error())
+		return
+	}
+	defer conn.Close(ctx)
+
+	_, err = conn.Exec(ctx, fmt.Sprintf("CREATE PUBLICATION %s FOR TABLE %s", pgx.Identifier{data.Name.ValueString()}.Sanitize(), quoteTables(tables)))
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create publication", err.Error())
+		return
+	}
+	data.ID = types.StringValue(fmt.Sprintf("%s/%s/%s/%s", data.ProjectID.ValueString(), data.BranchID.ValueString(), data.DatabaseName.ValueString(), data.Name.ValueString()))
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.State, resp.Identity)...)
+}
+
+func (r publicationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data publicationResourceModel
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	conn, err := openDatabase(ctx, r.client, data.ProjectID.ValueString(), data.BranchID.ValueString(), data.DatabaseName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to connect to database", err.Error())
+		return
+	}
+	defer conn.Close(ctx)
+
+	tables, err := readPublicationTables(ctx, conn, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read publication", err.Error())
+		return
+	}
+	if tables == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	aux, diags := types.SetValueFrom(ctx, types.StringType, tables)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Tables = aux
+	data.ID = types.StringValue(fmt.Sprintf("%s/%s/%s/%s", data.ProjectID.ValueString(), data.BranchID.ValueString(), data.DatabaseName.ValueString(), data.Name.ValueString()))
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.State, resp.Identity)...)
+}
+
+func (r publicationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data publicationResourceModel
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tables := []string{}
+	resp.Diagnostics.Append(data.Tables.ElementsAs(ctx, &tables, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	conn, err := openDatabase(ctx, r.client, data.ProjectID.ValueString(), data.BranchID.ValueString(), data.DatabaseName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to connect to database", err.Error())
+		return
+	}
+	defer conn.Close(ctx)
+
+	_, err = conn.Exec(ctx, fmt.Sprintf("ALTER PUBLICATION %s SET TABLE %s", pgx.Identifier{data.Name.ValueString()}.Sanitize(), quoteTables(tables)))
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to update publication", err.Error())
+		return
+	}
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.State, resp.Identity)...)
+}
+
+func (r publicationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data publicationResourceModel
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	conn, err := openDatabase(ctx, r.client, data.ProjectID.ValueString(), data.BranchID.ValueString(), data.DatabaseName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to connect to database", err.Error())
+		return
+	}
+	defer conn.Close(ctx)
+
+	_, err = conn.Exec(ctx, fmt.Sprintf("DROP PUBLICATION IF EXISTS %s", pgx.Identifier{data.Name.ValueString()}.Sanitize()))
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to delete publication", err.Error())
+		return
+	}
+	resp.State.RemoveResource(ctx)
+}
+
+func (r publicationResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identitySchema("project_id", "branch_id", "database_name", "name")
+}
+
+func (r publicationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importState(ctx, req, resp, "publication", "project_id/branch_id/database_name/name")
+}
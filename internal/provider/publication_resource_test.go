@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestPublicationResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckPostgres(t,
+				"CREATE TABLE IF NOT EXISTS public.tf_publication_a (id int primary key)",
+				"CREATE TABLE IF NOT EXISTS public.tf_publication_b (id int primary key)",
+			)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testPublicationResource(`["public.tf_publication_a"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_publication.test", "id", "project/branch/database/tf_publication"),
+					resource.TestCheckResourceAttr("neon_publication.test", "tables.#", "1"),
+					resource.TestCheckTypeSetElemAttr("neon_publication.test", "tables.*", "public.tf_publication_a"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "neon_publication.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testPublicationResource(`["public.tf_publication_a", "public.tf_publication_b"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_publication.test", "tables.#", "2"),
+					resource.TestCheckTypeSetElemAttr("neon_publication.test", "tables.*", "public.tf_publication_b"),
+				),
+			},
+		},
+	})
+}
+
+func testPublicationResource(tables string) string {
+	return `
+resource "neon_publication" "test" {
+	project_id = "project"
+	branch_id = "branch"
+	database_name = "database"
+	name = "tf_publication"
+	tables = ` + tables + `
+}
+`
+}
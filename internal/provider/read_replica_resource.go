@@ -0,0 +1,281 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.Resource = readReplicaResource{}
+var _ resource.ResourceWithImportState = readReplicaResource{}
+var _ resource.ResourceWithIdentity = readReplicaResource{}
+
+// readReplicaResource is a read_only endpoint on a branch that already has
+// its read_write endpoint.
+type readReplicaResource struct {
+	client *resty.Client
+}
+
+type readReplicaResourceModel struct {
+	ID                    types.String `tfsdk:"id"`
+	ProjectID             types.String `tfsdk:"project_id"`
+	BranchID              types.String `tfsdk:"branch_id"`
+	AutoscalingLimitMinCu types.Int64  `tfsdk:"autoscaling_limit_min_cu"`
+	AutoscalingLimitMaxCu types.Int64  `tfsdk:"autoscaling_limit_max_cu"`
+	RegionID              types.String `tfsdk:"region_id"`
+	Host                  types.String `tfsdk:"host"`
+	PoolerHost            types.String `tfsdk:"pooler_host"`
+	CurrentState          types.String `tfsdk:"current_state"`
+	CreatedAt             types.String `tfsdk:"created_at"`
+}
+
+func (in *endpointResourceJSON) toReadReplicaResourceModel() *readReplicaResourceModel {
+	return &readReplicaResourceModel{
+		ID:                    types.StringValue(in.Id),
+		ProjectID:             types.StringValue(in.ProjectID),
+		BranchID:              types.StringValue(in.BranchID),
+		AutoscalingLimitMinCu: types.Int64Value(in.AutoscalingLimitMinCu),
+		AutoscalingLimitMaxCu: types.Int64Value(in.AutoscalingLimitMaxCu),
+		RegionID:              types.StringValue(in.RegionID),
+		Host:                  types.StringValue(in.Host),
+		PoolerHost:            types.StringValue(poolerHost(in.Host)),
+		CurrentState:          types.StringValue(in.CurrentState),
+		CreatedAt:             types.StringValue(in.CreatedAt),
+	}
+}
+
+// branchPrimaryEndpoint returns the read_write endpoint of a branch, the one
+// its read replicas follow.
+func branchPrimaryEndpoint(client *resty.Client, projectID, branchID string) (*endpointResourceJSON, error) {
+	response, err := client.R().Get(fmt.Sprintf("/projects/%s/branches/%s/endpoints", projectID, branchID))
+	if err != nil {
+		return nil, err
+	}
+	if response.IsError() {
+		return nil, fmt.Errorf("failed to list branch endpoints with a status code: %s", response.Status())
+	}
+	endpoints := struct {
+		Endpoints []endpointResourceJSON `json:"endpoints"`
+	}{}
+	err = json.Unmarshal(response.Body(), &endpoints)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range endpoints.Endpoints {
+		if v.Type == "read_write" {
+			return &v, nil
+		}
+	}
+	return nil, fmt.Errorf("branch %s has no read_write endpoint, a read replica needs one to follow", branchID)
+}
+
+func (r readReplicaResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_read_replica"
+}
+
+func (r readReplicaResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Neon read replica resource, a read_only endpoint on a branch with a read_write endpoint",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "endpoint id",
+				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "project id",
+				Required:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"branch_id": schema.StringAttribute{
+				MarkdownDescription: "branch id, the branch must have a read_write endpoint",
+				Required:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"autoscaling_limit_min_cu": schema.Int64Attribute{
+				MarkdownDescription: "autoscaling limit min, defaults to the one of the read_write endpoint when created",
+				Computed:            true,
+				Optional:            true,
+				PlanModifiers:       []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
+			},
+			"autoscaling_limit_max_cu": schema.Int64Attribute{
+				MarkdownDescription: "autoscaling limit max, defaults to the one of the read_write endpoint when created",
+				Computed:            true,
+				Optional:            true,
+				PlanModifiers:       []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
+			},
+			"region_id": schema.StringAttribute{
+				MarkdownDescription: "region id",
+				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"host": schema.StringAttribute{
+				MarkdownDescription: "neon host",
+				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"pooler_host": schema.StringAttribute{
+				MarkdownDescription: "connection pooler host, for analytics tools opening many connections",
+				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"current_state": schema.StringAttribute{
+				MarkdownDescription: "current state",
+				Computed:            true,
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "created at",
+				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+		},
+	}
+}
+
+func (r readReplicaResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data readReplicaResourceModel
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	primary, err := branchPrimaryEndpoint(r.client, data.ProjectID.ValueString(), data.BranchID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Cannot create read replica", err.Error())
+		return
+	}
+	content := createEndpoint{
+		Branch_id:                data.BranchID.ValueString(),
+		Type:                     "read_only",
+		Autoscaling_limit_min_cu: primary.AutoscalingLimitMinCu,
+		Autoscaling_limit_max_cu: primary.AutoscalingLimitMaxCu,
+	}
+	if !data.AutoscalingLimitMinCu.IsUnknown() && !data.AutoscalingLimitMinCu.IsNull() {
+		content.Autoscaling_limit_min_cu = data.AutoscalingLimitMinCu.ValueInt64()
+	}
+	if !data.AutoscalingLimitMaxCu.IsUnknown() && !data.AutoscalingLimitMaxCu.IsNull() {
+		content.Autoscaling_limit_max_cu = data.AutoscalingLimitMaxCu.ValueInt64()
+	}
+	response, err := r.client.R().
+		SetBody(struct {
+			Endpoint createEndpoint `json:"endpoint"`
+		}{content}).
+		Post(fmt.Sprintf("/projects/%s/endpoints", data.ProjectID.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create read replica", err.Error())
+		return
+	}
+	if response.IsError() {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to create read replica with a status code: %s", response.Status()), string(response.Body()))
+		return
+	}
+	r.setState(ctx, response.Body(), &resp.State, resp.Identity, &resp.Diagnostics)
+}
+
+func (r readReplicaResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data readReplicaResourceModel
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// the replica is read on its own, changes to the read_write endpoint of
+	// the branch do not show up as changes to the replica
+	response, err := r.client.R().Get(fmt.Sprintf("/projects/%s/endpoints/%s", data.ProjectID.ValueString(), data.ID.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read read replica", err.Error())
+		return
+	}
+	if response.IsError() {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to read read replica with a status code: %s", response.Status()), "")
+		return
+	}
+	r.setState(ctx, response.Body(), &resp.State, resp.Identity, &resp.Diagnostics)
+}
+
+func (r readReplicaResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data readReplicaResourceModel
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	content := struct {
+		Endpoint updateEndpoint `json:"endpoint"`
+	}{
+		Endpoint: updateEndpoint{
+			Branch_id:                data.BranchID.ValueString(),
+			Autoscaling_limit_min_cu: data.AutoscalingLimitMinCu.ValueInt64(),
+			Autoscaling_limit_max_cu: data.AutoscalingLimitMaxCu.ValueInt64(),
+		},
+	}
+	response, err := r.client.R().
+		SetBody(content).
+		Patch(fmt.Sprintf("/projects/%s/endpoints/%s", data.ProjectID.ValueString(), data.ID.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to update read replica", err.Error())
+		return
+	}
+	if response.IsError() {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to update read replica with a status code: %s", response.Status()), string(response.Body()))
+		return
+	}
+	r.setState(ctx, response.Body(), &resp.State, resp.Identity, &resp.Diagnostics)
+}
+
+func (r readReplicaResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data readReplicaResourceModel
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	response, err := r.client.R().Delete(fmt.Sprintf("/projects/%s/endpoints/%s", data.ProjectID.ValueString(), data.ID.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to delete read replica", err.Error())
+		return
+	}
+	if response.IsError() {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to delete read replica with a status code: %s", response.Status()), "")
+		return
+	}
+	resp.State.RemoveResource(ctx)
+}
+
+// setState stores the endpoint returned by the api, refusing endpoints that
+// are not read_only so a read_write endpoint is never managed as a replica.
+func (r readReplicaResource) setState(ctx context.Context, body []byte, state *tfsdk.State, identity *tfsdk.ResourceIdentity, diags *diag.Diagnostics) {
+	endpoint := struct {
+		Endpoint endpointResourceJSON `json:"endpoint"`
+	}{}
+	err := json.Unmarshal(body, &endpoint)
+	if err != nil {
+		diags.AddError("Failed to unmarshal response", err.Error())
+		return
+	}
+	if endpoint.Endpoint.Type != "read_only" {
+		diags.AddError("Not a read replica", fmt.Sprintf("endpoint %s is %s, use neon_endpoint to manage it", endpoint.Endpoint.Id, endpoint.Endpoint.Type))
+		return
+	}
+	diags.Append(state.Set(ctx, endpoint.Endpoint.toReadReplicaResourceModel())...)
+	diags.Append(setIdentity(ctx, *state, identity)...)
+}
+
+func (r readReplicaResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identitySchema("project_id", "id")
+}
+
+func (r readReplicaResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importState(ctx, req, resp, "read replica", "project_id/id")
+}
@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestReadReplicaResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// A branch without a read_write endpoint cannot have replicas
+			{
+				Config:      testReadReplicaResourceWithoutPrimary(),
+				ExpectError: regexp.MustCompile("has no read_write endpoint"),
+			},
+			{
+				Config: testReadReplicaResourceCreate(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("neon_read_replica.test", "branch_id", "neon_project.test", "branch.id"),
+					resource.TestCheckResourceAttrPair("neon_read_replica.test", "autoscaling_limit_min_cu", "neon_project.test", "endpoints.0.autoscaling_limit_min_cu"),
+					resource.TestCheckResourceAttrPair("neon_read_replica.test", "autoscaling_limit_max_cu", "neon_project.test", "endpoints.0.autoscaling_limit_max_cu"),
+					resource.TestCheckResourceAttrSet("neon_read_replica.test", "host"),
+					resource.TestMatchResourceAttr("neon_read_replica.test", "pooler_host", regexp.MustCompile(`^ep-[a-z0-9-]+-pooler\.`)),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "neon_read_replica.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					if rs, ok := s.RootModule().Resources["neon_read_replica.test"]; ok {
+						return fmt.Sprintf("%s/%s", rs.Primary.Attributes["project_id"], rs.Primary.ID), nil
+					}
+					return "", fmt.Errorf("cannot find neon_read_replica.test")
+				},
+			},
+		},
+	})
+}
+
+func testReadReplicaResourceWithoutPrimary() string {
+	return `
+resource "neon_project" "test" {
+	name = "name_project"
+}
+
+resource "neon_branch" "test" {
+	project_id = neon_project.test.id
+	name = "name_branch"
+}
+
+resource "neon_read_replica" "test" {
+	project_id = neon_project.test.id
+	branch_id = neon_branch.test.id
+}
+`
+}
+
+func testReadReplicaResourceCreate() string {
+	return `
+resource "neon_project" "test" {
+	name = "name_project"
+}
+
+resource "neon_read_replica" "test" {
+	project_id = neon_project.test.id
+	branch_id = neon_project.test.branch.id
+}
+`
+}
+
+func TestBranchPrimaryEndpoint(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/projects/p-1/branches/br-main/endpoints", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"endpoints":[{"id":"ep-2","type":"read_only"},{"id":"ep-1","type":"read_write","autoscaling_limit_min_cu":1,"autoscaling_limit_max_cu":4}]}`)
+	})
+	mux.HandleFunc("/projects/p-1/branches/br-dev/endpoints", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"endpoints":[{"id":"ep-3","type":"read_only"}]}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	client := resty.New().SetBaseURL(server.URL)
+
+	primary, err := branchPrimaryEndpoint(client, "p-1", "br-main")
+	if err != nil {
+		t.Fatalf("branchPrimaryEndpoint() error = %s", err)
+	}
+	if primary.Id != "ep-1" || primary.AutoscalingLimitMinCu != 1 || primary.AutoscalingLimitMaxCu != 4 {
+		t.Errorf("branchPrimaryEndpoint() = %+v, want ep-1 scaling 1-4", primary)
+	}
+	if _, err := branchPrimaryEndpoint(client, "p-1", "br-dev"); err == nil {
+		t.Errorf("branchPrimaryEndpoint() without a read_write endpoint error = nil")
+	}
+}
@@ -0,0 +1,186 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &regionsDataSource{}
+
+type regionsDataSource struct {
+	client *resty.Client
+}
+
+type regionsDataModel struct {
+	ID      types.String `tfsdk:"id"`
+	Regions types.List   `tfsdk:"regions"`
+}
+
+type regionDataModel struct {
+	ID      types.String `tfsdk:"id"`
+	Name    types.String `tfsdk:"name"`
+	Default types.Bool   `tfsdk:"default"`
+}
+
+type regionDataJSON struct {
+	RegionID string `json:"region_id"`
+	Name     string `json:"name"`
+	Default  bool   `json:"default"`
+}
+
+func (in *regionDataJSON) ToRegionDataModel() *regionDataModel {
+	return &regionDataModel{
+		ID:      types.StringValue(in.RegionID),
+		Name:    types.StringValue(in.Name),
+		Default: types.BoolValue(in.Default),
+	}
+}
+
+func regionsDataRegionAttr() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			MarkdownDescription: "region id",
+			Computed:            true,
+		},
+		"name": schema.StringAttribute{
+			MarkdownDescription: "region name",
+			Computed:            true,
+		},
+		"default": schema.BoolAttribute{
+			MarkdownDescription: "whether new projects are created in this region by default",
+			Computed:            true,
+		},
+	}
+}
+
+func listRegions(client *resty.Client) ([]regionDataJSON, error) {
+	response, err := client.R().Get("/regions")
+	if err != nil {
+		return nil, err
+	}
+	if response.IsError() {
+		return nil, fmt.Errorf("failed to list regions with a status code: %s", response.Status())
+	}
+	regions := struct {
+		Regions []regionDataJSON `json:"regions"`
+	}{}
+	err = json.Unmarshal(response.Body(), &regions)
+	if err != nil {
+		return nil, err
+	}
+	return regions.Regions, nil
+}
+
+// regionCache holds the region list fetched once per provider configuration.
+// A nil *regionCache means region validation is disabled.
+type regionCache struct {
+	client  *resty.Client
+	once    sync.Once
+	regions []regionDataJSON
+	err     error
+}
+
+func (c *regionCache) get() ([]regionDataJSON, error) {
+	c.once.Do(func() {
+		c.regions, c.err = listRegions(c.client)
+	})
+	return c.regions, c.err
+}
+
+// validatePlan checks the planned region_id against the cached region list.
+// It only warns when the list cannot be fetched.
+func (c *regionCache) validatePlan(ctx context.Context, plan tfsdk.Plan) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if c == nil || plan.Raw.IsNull() {
+		return diags
+	}
+	var regionID types.String
+	diags.Append(plan.GetAttribute(ctx, path.Root("region_id"), &regionID)...)
+	if diags.HasError() || regionID.IsNull() || regionID.IsUnknown() {
+		return diags
+	}
+	regions, err := c.get()
+	if err != nil {
+		diags.AddAttributeWarning(path.Root("region_id"), "Unable to validate region_id", err.Error())
+		return diags
+	}
+	ids := []string{}
+	for _, v := range regions {
+		if v.RegionID == regionID.ValueString() {
+			return diags
+		}
+		ids = append(ids, v.RegionID)
+	}
+	diags.AddAttributeError(
+		path.Root("region_id"),
+		"Invalid region_id",
+		fmt.Sprintf("Region '%s' is not supported, should be one of: %s", regionID.ValueString(), strings.Join(ids, ", ")),
+	)
+	return diags
+}
+
+// Metadata implements datasource.DataSource
+func (*regionsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_regions"
+}
+
+// Read implements datasource.DataSource
+func (d *regionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data regionsDataModel
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	regions, err := listRegions(d.client)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to list regions", err.Error())
+		return
+	}
+	r := []regionDataModel{}
+	for _, v := range regions {
+		r = append(r, *v.ToRegionDataModel())
+	}
+	aux, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: typeFromDataAttrs(regionsDataRegionAttr())}, r)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Regions = aux
+	data.ID = types.StringValue("regions")
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Schema implements datasource.DataSource
+func (*regionsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "placeholder identifier",
+				Computed:            true,
+			},
+			"regions": schema.ListNestedAttribute{
+				MarkdownDescription: "supported regions",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: regionsDataRegionAttr(),
+				},
+			},
+		},
+	}
+}
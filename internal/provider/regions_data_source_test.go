@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestRegionsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testRegionsDataSource(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.neon_regions.test", "regions.0.id"),
+					resource.TestCheckResourceAttrSet("data.neon_regions.test", "regions.0.name"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.neon_regions.test", "regions.*", map[string]string{
+						"id": "aws-us-east-2",
+					}),
+				),
+			},
+			{
+				Config:      testRegionsValidation(),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Invalid region_id"),
+			},
+		},
+	})
+}
+
+func testRegionsDataSource() string {
+	return `
+data "neon_regions" "test" {}
+`
+}
+
+func testRegionsValidation() string {
+	return `
+provider "neon" {
+	validate_regions = true
+}
+
+resource "neon_project" "test" {
+	name = "name_project"
+	region_id = "aws-us-east-42"
+}
+`
+}
@@ -0,0 +1,196 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jackc/pgx/v5"
+)
+
+// replicationSlotNameRegex matches the names postgres accepts for
+// replication slots.
+var replicationSlotNameRegex = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+type replicationSlotResource struct {
+	client *resty.Client
+}
+type replicationSlotResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	ProjectID    types.String `tfsdk:"project_id"`
+	BranchID     types.String `tfsdk:"branch_id"`
+	DatabaseName types.String `tfsdk:"database_name"`
+	Name         types.String `tfsdk:"name"`
+	Plugin       types.String `tfsdk:"plugin"`
+}
+
+var _ resource.Resource = replicationSlotResource{}
+var _ resource.ResourceWithImportState = replicationSlotResource{}
+var _ resource.ResourceWithIdentity = replicationSlotResource{}
+
+// readReplicationSlotPlugin returns the output plugin of a logical
+// replication slot of the database, or an empty string when the slot does
+// not exist.
+func readReplicationSlotPlugin(ctx context.Context, conn *pgx.Conn, name string) (string, error) {
+	var plugin string
+	err := conn.QueryRow(ctx, "SELECT plugin FROM pg_replication_slots WHERE slot_name = $1 AND database = current_database()", name).Scan(&plugin)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+	return plugin, err
+}
+
+func (r replicationSlotResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_replication_slot"
+}
+
+func (r replicationSlotResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Postgres logical replication slot, managed by connecting to the branch read_write endpoint as the database owner. The project needs enable_logical_replication. A slot nobody consumes keeps the WAL from being removed",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "replication slot identifier",
+				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "project id",
+				Required:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"branch_id": schema.StringAttribute{
+				MarkdownDescription: "branch id",
+				Required:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"database_name": schema.StringAttribute{
+				MarkdownDescription: "database name",
+				Required:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "replication slot name",
+				Required:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(replicationSlotNameRegex, "must contain only lower case letters, numbers and underscores"),
+				},
+			},
+			"plugin": schema.StringAttribute{
+				MarkdownDescription: "output plugin, pgoutput for publications",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("pgoutput"),
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+		},
+	}
+}
+
+func (r replicationSlotResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data replicationSlotResourceModel
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	conn, err := openDatabase(ctx, r.client, data.ProjectID.ValueString(), data.BranchID.ValueString(), data.DatabaseName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to connect to database", err.Error())
+		return
+	}
+	defer conn.Close(ctx)
+
+	_, err = conn.Exec(ctx, "SELECT pg_create_logical_replication_slot($1, $2)", data.Name.ValueString(), data.Plugin.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create replication slot", err.Error())
+		return
+	}
+	data.ID = types.StringValue(fmt.Sprintf("%s/%s/%s/%s", data.ProjectID.ValueString(), data.BranchID.ValueString(), data.DatabaseName.ValueString(), data.Name.ValueString()))
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.State, resp.Identity)...)
+}
+
+func (r replicationSlotResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data replicationSlotResourceModel
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	conn, err := openDatabase(ctx, r.client, data.ProjectID.ValueString(), data.BranchID.ValueString(), data.DatabaseName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to connect to database", err.Error())
+		return
+	}
+	defer conn.Close(ctx)
+
+	plugin, err := readReplicationSlotPlugin(ctx, conn, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read replication slot", err.Error())
+		return
+	}
+	if plugin == "" {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	data.Plugin = types.StringValue(plugin)
+	data.ID = types.StringValue(fmt.Sprintf("%s/%s/%s/%s", data.ProjectID.ValueString(), data.BranchID.ValueString(), data.DatabaseName.ValueString(), data.Name.ValueString()))
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.State, resp.Identity)...)
+}
+
+// Update only stores the plan, every attribute of a slot requires replacing it.
+func (r replicationSlotResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data replicationSlotResourceModel
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.State, resp.Identity)...)
+}
+
+func (r replicationSlotResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data replicationSlotResourceModel
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	conn, err := openDatabase(ctx, r.client, data.ProjectID.ValueString(), data.BranchID.ValueString(), data.DatabaseName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to connect to database", err.Error())
+		return
+	}
+	defer conn.Close(ctx)
+
+	_, err = conn.Exec(ctx, "SELECT pg_drop_replication_slot(slot_name) FROM pg_replication_slots WHERE slot_name = $1 AND database = current_database()", data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to delete replication slot", err.Error())
+		return
+	}
+	resp.State.RemoveResource(ctx)
+}
+
+func (r replicationSlotResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identitySchema("project_id", "branch_id", "database_name", "name")
+}
+
+func (r replicationSlotResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importState(ctx, req, resp, "replication slot", "project_id/branch_id/database_name/name")
+}
@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/jackc/pgx/v5"
+)
+
+func TestReplicationSlotResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckPostgres(t)
+			testAccPreCheckLogicalReplication(t)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testReplicationSlotResource(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_replication_slot.test", "id", "project/branch/database/tf_replication_slot"),
+					resource.TestCheckResourceAttr("neon_replication_slot.test", "plugin", "pgoutput"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "neon_replication_slot.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// testAccPreCheckLogicalReplication skips tests creating replication slots
+// when the local postgres does not run with wal_level = logical.
+func testAccPreCheckLogicalReplication(t *testing.T) {
+	conn, err := pgx.Connect(context.Background(), os.Getenv("NEON_TEST_POSTGRES_DSN"))
+	if err != nil {
+		t.Fatalf("cannot connect to NEON_TEST_POSTGRES_DSN: %s", err)
+	}
+	defer conn.Close(context.Background())
+	var walLevel string
+	if err := conn.QueryRow(context.Background(), "SHOW wal_level").Scan(&walLevel); err != nil {
+		t.Fatalf("cannot read wal_level: %s", err)
+	}
+	if walLevel != "logical" {
+		t.Skipf("wal_level is %s, logical replication slots need logical", walLevel)
+	}
+}
+
+func testReplicationSlotResource() string {
+	return `
+resource "neon_replication_slot" "test" {
+	project_id = "project"
+	branch_id = "branch"
+	database_name = "database"
+	name = "tf_replication_slot"
+}
+`
+}
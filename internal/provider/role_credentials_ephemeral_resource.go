@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ ephemeral.EphemeralResource = &roleCredentialsEphemeralResource{}
+
+type roleCredentialsEphemeralResource struct {
+	client *resty.Client
+}
+
+type roleCredentialsEphemeralModel struct {
+	ProjectID types.String `tfsdk:"project_id"`
+	BranchID  types.String `tfsdk:"branch_id"`
+	Name      types.String `tfsdk:"name"`
+	Reset     types.Bool   `tfsdk:"reset"`
+	User      types.String `tfsdk:"user"`
+	Password  types.String `tfsdk:"password"`
+}
+
+// Metadata implements ephemeral.EphemeralResource
+func (*roleCredentialsEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role_credentials"
+}
+
+// Schema implements ephemeral.EphemeralResource
+func (*roleCredentialsEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Role credentials that are never stored in state.\n\n" +
+			"~> **Warning:** with `reset = true` the password is reset every time Terraform opens the resource. " +
+			"Terraform opens it in `terraform plan` and again in `terraform apply`, so a plan followed by an apply " +
+			"resets the password at least twice and the password used during the apply is not the one seen during " +
+			"the plan. The `password` of the matching `neon_role` in state is stale until the next refresh, and " +
+			"clients using an earlier password are disconnected.",
+		Attributes: map[string]schema.Attribute{
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "project id",
+				Required:            true,
+			},
+			"branch_id": schema.StringAttribute{
+				MarkdownDescription: "branch id",
+				Required:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "role name",
+				Required:            true,
+			},
+			"reset": schema.BoolAttribute{
+				MarkdownDescription: "reset the password instead of revealing the current one, every time the resource is opened",
+				Optional:            true,
+			},
+			"user": schema.StringAttribute{
+				MarkdownDescription: "role name to connect with",
+				Computed:            true,
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "role password",
+				Computed:            true,
+				Sensitive:           true,
+			},
+		},
+	}
+}
+
+// Open implements ephemeral.EphemeralResource
+func (e *roleCredentialsEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data roleCredentialsEphemeralModel
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var password string
+	if data.Reset.ValueBool() {
+		role, err := resetRolePassword(e.client, data.ProjectID.ValueString(), data.BranchID.ValueString(), data.Name.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to reset role password", err.Error())
+			return
+		}
+		password = role.Password
+	} else {
+		var err error
+		password, err = revealRolePassword(e.client, data.ProjectID.ValueString(), data.BranchID.ValueString(), data.Name.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to reveal role password", err.Error())
+			return
+		}
+	}
+
+	data.User = data.Name
+	data.Password = types.StringValue(password)
+	diags = resp.Result.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestRoleCredentialsEphemeralResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckEphemeral(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testRoleCredentialsEphemeralResource(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_role.test", "name", "name_role"),
+				),
+			},
+		},
+	})
+}
+
+func testRoleCredentialsEphemeralResource() string {
+	return `
+resource "neon_project" "test" {
+	name = "name_project"
+}
+
+resource "neon_role" "test" {
+	project_id = neon_project.test.id
+	branch_id = neon_project.test.branch.id
+	name = "name_role"
+}
+
+ephemeral "neon_role_credentials" "test" {
+	project_id = neon_role.test.project_id
+	branch_id = neon_role.test.branch_id
+	name = neon_role.test.name
+}
+`
+}
+
+func TestRoleCredentialsOpen(t *testing.T) {
+	revealed, reset := 0, 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/projects/p-1/branches/br-main/roles/app/reveal_password", func(w http.ResponseWriter, r *http.Request) {
+		revealed++
+		fmt.Fprint(w, `{"password":"current"}`)
+	})
+	mux.HandleFunc("/projects/p-1/branches/br-main/roles/app/reset_password", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "unexpected method", http.StatusMethodNotAllowed)
+			return
+		}
+		reset++
+		fmt.Fprintf(w, `{"role":{"name":"app","branch_id":"br-main","password":"reset-%d"}}`, reset)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	ctx := context.Background()
+	e := &roleCredentialsEphemeralResource{
+		client: resty.New().SetBaseURL(server.URL),
+	}
+	schema := ephemeral.SchemaResponse{}
+	e.Schema(ctx, ephemeral.SchemaRequest{}, &schema)
+	open := func(resetPassword bool) roleCredentialsEphemeralModel {
+		t.Helper()
+		objectType := schema.Schema.Type().TerraformType(ctx)
+		config := tfsdk.Config{
+			Schema: schema.Schema,
+			Raw: tftypes.NewValue(objectType, map[string]tftypes.Value{
+				"project_id": tftypes.NewValue(tftypes.String, "p-1"),
+				"branch_id":  tftypes.NewValue(tftypes.String, "br-main"),
+				"name":       tftypes.NewValue(tftypes.String, "app"),
+				"reset":      tftypes.NewValue(tftypes.Bool, resetPassword),
+				"user":       tftypes.NewValue(tftypes.String, nil),
+				"password":   tftypes.NewValue(tftypes.String, nil),
+			}),
+		}
+		resp := ephemeral.OpenResponse{
+			Result: tfsdk.EphemeralResultData{Schema: schema.Schema, Raw: config.Raw.Copy()},
+		}
+		e.Open(ctx, ephemeral.OpenRequest{Config: config}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Open() = %v", resp.Diagnostics)
+		}
+		data := roleCredentialsEphemeralModel{}
+		resp.Diagnostics.Append(resp.Result.Get(ctx, &data)...)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Open() result = %v", resp.Diagnostics)
+		}
+		return data
+	}
+
+	data := open(false)
+	if data.User.ValueString() != "app" || data.Password.ValueString() != "current" || revealed != 1 || reset != 0 {
+		t.Errorf("Open() = %s/%s with %d reveals and %d resets, want app/current revealed", data.User, data.Password, revealed, reset)
+	}
+	data = open(true)
+	if data.Password.ValueString() != "reset-1" || reset != 1 {
+		t.Errorf("Open() with reset = %s with %d resets, want reset-1", data.Password, reset)
+	}
+	// every open resets the password again, as the plan and the apply do
+	data = open(true)
+	if data.Password.ValueString() != "reset-2" || reset != 2 || revealed != 1 {
+		t.Errorf("Open() with reset again = %s with %d resets, want reset-2", data.Password, reset)
+	}
+}
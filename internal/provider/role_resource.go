@@ -4,13 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"strings"
 
 	"github.com/go-resty/resty/v2"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -22,21 +24,34 @@ type roleResourceJSON struct {
 	BranchID  string `json:"branch_id"`
 	Name      string `json:"name"`
 	Protected bool   `json:"protected"`
+	Password  string `json:"password,omitempty"`
 	CreatedAt string `json:"created_at"`
 	UpdatedAt string `json:"updated_at"`
 }
 type roleResourceModel struct {
-	ProjectID types.String `tfsdk:"project_id"`
-	BranchID  types.String `tfsdk:"branch_id"`
-	Name      types.String `tfsdk:"name"`
-	Protected types.Bool   `tfsdk:"protected"`
-	CreatedAt types.String `tfsdk:"created_at"`
-	UpdatedAt types.String `tfsdk:"updated_at"`
-	ID        types.String `tfsdk:"id"`
+	ProjectID               types.String `tfsdk:"project_id"`
+	BranchID                types.String `tfsdk:"branch_id"`
+	Name                    types.String `tfsdk:"name"`
+	Protected               types.Bool   `tfsdk:"protected"`
+	Password                types.String `tfsdk:"password"`
+	PasswordRotationTrigger types.Map    `tfsdk:"password_rotation_trigger"`
+	CreatedAt               types.String `tfsdk:"created_at"`
+	UpdatedAt               types.String `tfsdk:"updated_at"`
+	ID                      types.String `tfsdk:"id"`
+}
+
+// roleResourceWithAttributesModel is the neon_role state, the nested project
+// roles only carry roleResourceModel.
+type roleResourceWithAttributesModel struct {
+	roleResourceModel
+	roleAttributesModel
 }
 
 var _ resource.Resource = roleResource{}
+var _ resource.ResourceWithUpgradeState = roleResource{}
 var _ resource.ResourceWithImportState = roleResource{}
+var _ resource.ResourceWithIdentity = roleResource{}
+var _ resource.ResourceWithModifyPlan = roleResource{}
 
 func roleResourceAttr() map[string]schema.Attribute {
 	return map[string]schema.Attribute{
@@ -55,6 +70,17 @@ func roleResourceAttr() map[string]schema.Attribute {
 		"protected": schema.BoolAttribute{
 			Computed: true,
 		},
+		"password": schema.StringAttribute{
+			MarkdownDescription: "role password",
+			Computed:            true,
+			Sensitive:           true,
+			PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+		},
+		"password_rotation_trigger": schema.MapAttribute{
+			MarkdownDescription: "arbitrary values that reset the role password when changed",
+			ElementType:         types.StringType,
+			Optional:            true,
+		},
 		"created_at": schema.StringAttribute{
 			Computed: true,
 		},
@@ -69,33 +95,132 @@ func (v *roleResourceModel) toRoleJSON(ctx context.Context) *roleResourceJSON {
 		BranchID:  v.BranchID.ValueString(),
 		Name:      v.Name.ValueString(),
 		Protected: v.Protected.ValueBool(),
-		CreatedAt: v.UpdatedAt.ValueString(),
+		Password:  v.Password.ValueString(),
+		CreatedAt: v.CreatedAt.ValueString(),
 		UpdatedAt: v.UpdatedAt.ValueString(),
 		ProjectID: v.ProjectID.ValueString(),
 	}
 }
 
+func (v *roleResourceJSON) toRoleResourceModel(project_id string) *roleResourceModel {
+	password := types.StringNull()
+	if v.Password != "" {
+		password = types.StringValue(v.Password)
+	}
+	return &roleResourceModel{
+		BranchID:                types.StringValue(v.BranchID),
+		Name:                    types.StringValue(v.Name),
+		Protected:               types.BoolValue(v.Protected),
+		Password:                password,
+		PasswordRotationTrigger: types.MapNull(types.StringType),
+		CreatedAt:               types.StringValue(v.CreatedAt),
+		UpdatedAt:               types.StringValue(v.UpdatedAt),
+		ProjectID:               types.StringValue(project_id),
+		ID:                      types.StringValue(v.Name),
+	}
+}
+
 func (v *roleResourceJSON) toRoleModel(ctx context.Context, project_id string) (types.Object, diag.Diagnostics) {
-	db := roleResourceModel{
-		BranchID:  types.StringValue(v.BranchID),
-		Name:      types.StringValue(v.Name),
-		Protected: types.BoolValue(v.Protected),
-		CreatedAt: types.StringValue(v.CreatedAt),
-		UpdatedAt: types.StringValue(v.UpdatedAt),
-		ProjectID: types.StringValue(project_id),
-		ID:        types.StringValue(v.Name),
+	return types.ObjectValueFrom(ctx, typeFromAttrs(roleResourceAttr()), v.toRoleResourceModel(project_id))
+}
+
+func revealRolePassword(client *resty.Client, projectID, branchID, name string) (string, error) {
+	response, err := client.R().Get(fmt.Sprintf("/projects/%s/branches/%s/roles/%s/reveal_password", projectID, branchID, name))
+	if err != nil {
+		return "", err
+	}
+	if response.IsError() {
+		return "", fmt.Errorf("failed to reveal role password with a status code: %s", response.Status())
+	}
+	inner := struct {
+		Password string `json:"password"`
+	}{}
+	err = json.Unmarshal(response.Body(), &inner)
+	if err != nil {
+		return "", err
 	}
-	return types.ObjectValueFrom(ctx, typeFromAttrs(roleResourceAttr()), db)
+	return inner.Password, nil
+}
+
+func resetRolePassword(client *resty.Client, projectID, branchID, name string) (*roleResourceJSON, error) {
+	response, err := client.R().Post(fmt.Sprintf("/projects/%s/branches/%s/roles/%s/reset_password", projectID, branchID, name))
+	if err != nil {
+		return nil, err
+	}
+	if response.IsError() {
+		return nil, fmt.Errorf("failed to reset role password with a status code: %s", response.Status())
+	}
+	inner := struct {
+		Role roleResourceJSON `json:"role"`
+	}{}
+	err = json.Unmarshal(response.Body(), &inner)
+	if err != nil {
+		return nil, err
+	}
+	return &inner.Role, nil
 }
 
 func (r roleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := roleResourceAttr()
+	for k, v := range roleAttributesResourceAttr() {
+		attributes[k] = v
+	}
+	// The api cannot rename or move a role. The nested project roles keep
+	// the plain attributes since they are computed.
+	for _, k := range []string{"name", "branch_id", "project_id"} {
+		attributes[k] = schema.StringAttribute{
+			Required:      true,
+			PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+		}
+	}
+	for _, k := range []string{"id", "created_at"} {
+		addStringPlanModifiers(attributes, k, stringplanmodifier.UseStateForUnknown())
+	}
+	addBoolPlanModifiers(attributes, "protected", boolplanmodifier.UseStateForUnknown())
 	resp.Schema = schema.Schema{
-		Attributes: roleResourceAttr(),
+		Version:    1,
+		Attributes: attributes,
+	}
+}
+
+func (r roleResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: upgradeFromV0(r),
+	}
+}
+
+// syncRoleAttributes applies the sql attributes of plan, moving them from
+// state, and reads them back. state is nil on create.
+func (r roleResource) syncRoleAttributes(ctx context.Context, projectID, branchID, role string, plan, state *roleAttributesModel) error {
+	if !plan.isSet() && (state == nil || !state.isSet()) {
+		return nil
+	}
+	conn, err := openBranchDatabase(ctx, r.client, projectID, branchID)
+	if err != nil {
+		return err
 	}
+	defer conn.Close(ctx)
+
+	planAttributes, err := plan.toRoleAttributes(ctx)
+	if err != nil {
+		return err
+	}
+	var stateAttributes *roleAttributes
+	if state != nil {
+		stateAttributes, err = state.toRoleAttributes(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	err = applyRoleAttributes(ctx, conn, role, planAttributes, stateAttributes)
+	if err != nil {
+		return err
+	}
+	return plan.read(ctx, conn, role)
 }
 
 func (r roleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data roleResourceModel
+	var data roleResourceWithAttributesModel
 	diags := req.Plan.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -130,13 +255,16 @@ func (r roleResource) Create(ctx context.Context, req resource.CreateRequest, re
 		resp.Diagnostics.AddError("Failed to unmarshal response", err.Error())
 		return
 	}
-	roleObj, diags := inner.Role.toRoleModel(ctx, data.ProjectID.ValueString())
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
+	role := inner.Role.toRoleResourceModel(data.ProjectID.ValueString())
+	role.PasswordRotationTrigger = data.PasswordRotationTrigger
+	err = r.syncRoleAttributes(ctx, data.ProjectID.ValueString(), data.BranchID.ValueString(), data.Name.ValueString(), &data.roleAttributesModel, nil)
+	if err != nil {
+		// The role exists, keep it in state so the attributes are retried.
+		resp.Diagnostics.AddError("Failed to set role attributes", err.Error())
 	}
-	diags = resp.State.Set(ctx, roleObj)
+	diags = resp.State.Set(ctx, &roleResourceWithAttributesModel{*role, data.roleAttributesModel})
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.State, resp.Identity)...)
 }
 
 func (r roleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -159,7 +287,7 @@ func (r roleResource) Metadata(ctx context.Context, req resource.MetadataRequest
 }
 
 func (r roleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var data roleResourceModel
+	var data roleResourceWithAttributesModel
 	diags := req.State.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -179,28 +307,91 @@ func (r roleResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 		resp.Diagnostics.AddError("Failed to unmarshal response", err.Error())
 		return
 	}
-	roleObj, diags := inner.Role.toRoleModel(ctx, data.ProjectID.ValueString())
+	password, err := revealRolePassword(r.client, data.ProjectID.ValueString(), data.BranchID.ValueString(), data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to reveal role password", err.Error())
+		return
+	}
+	inner.Role.Password = password
+	role := inner.Role.toRoleResourceModel(data.ProjectID.ValueString())
+	role.PasswordRotationTrigger = data.PasswordRotationTrigger
+	if data.roleAttributesModel.isSet() {
+		conn, err := openBranchDatabase(ctx, r.client, data.ProjectID.ValueString(), data.BranchID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to connect to database", err.Error())
+			return
+		}
+		defer conn.Close(ctx)
+		err = data.roleAttributesModel.read(ctx, conn, data.Name.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to read role attributes", err.Error())
+			return
+		}
+	}
+	diags = resp.State.Set(ctx, &roleResourceWithAttributesModel{*role, data.roleAttributesModel})
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.State, resp.Identity)...)
+}
+
+func (r roleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data roleResourceWithAttributesModel
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state roleResourceWithAttributesModel
+	diags = req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	diags = resp.State.Set(ctx, roleObj)
+	if !data.PasswordRotationTrigger.Equal(state.PasswordRotationTrigger) {
+		role, err := resetRolePassword(r.client, state.ProjectID.ValueString(), state.BranchID.ValueString(), state.Name.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to reset role password", err.Error())
+			return
+		}
+		state.Password = types.StringValue(role.Password)
+		state.UpdatedAt = types.StringValue(role.UpdatedAt)
+	}
+	state.PasswordRotationTrigger = data.PasswordRotationTrigger
+	err := r.syncRoleAttributes(ctx, state.ProjectID.ValueString(), state.BranchID.ValueString(), state.Name.ValueString(), &data.roleAttributesModel, &state.roleAttributesModel)
+	if err != nil {
+		// Keep a reset password even though the attributes failed.
+		resp.Diagnostics.AddError("Failed to update role attributes", err.Error())
+		resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+		resp.Diagnostics.Append(setIdentity(ctx, resp.State, resp.Identity)...)
+		return
+	}
+	state.roleAttributesModel = data.roleAttributesModel
+	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.State, resp.Identity)...)
 }
 
-func (r roleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddWarning("Update role resource not implemented", "")
+// ModifyPlan marks the password as unknown when the rotation trigger changes,
+// since Update is going to reset it.
+func (r roleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+	var plan, state types.Map
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("password_rotation_trigger"), &plan)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("password_rotation_trigger"), &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !plan.Equal(state) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("password"), types.StringUnknown())...)
+	}
+}
+
+func (r roleResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identitySchema("project_id", "branch_id", "name")
 }
 
 func (r roleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	ids := strings.Split(req.ID, "/")
-	if len(ids) != 3 || ids[0] == "" || ids[1] == "" || ids[2] == "" {
-		resp.Diagnostics.AddError(
-			"Cannot import branch",
-			fmt.Sprintf("Invalid id '%s' specified. should be in format \"project_id/branch_id/name\"", req.ID),
-		)
-	}
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), ids[0])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("branch_id"), ids[1])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), ids[2])...)
+	importState(ctx, req, resp, "role", "project_id/branch_id/name")
 }
@@ -0,0 +1,340 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jackc/pgx/v5"
+)
+
+// roleAttributesModel holds the role attributes that the control plane does
+// not manage. They are applied with ALTER ROLE and GRANT through the branch
+// read_write endpoint, and only the ones set in the configuration are read
+// back.
+type roleAttributesModel struct {
+	ConnectionLimit  types.Int64  `tfsdk:"connection_limit"`
+	Login            types.Bool   `tfsdk:"login"`
+	CreateDB         types.Bool   `tfsdk:"create_db"`
+	CreateRole       types.Bool   `tfsdk:"create_role"`
+	InRoles          types.Set    `tfsdk:"in_roles"`
+	SearchPath       types.List   `tfsdk:"search_path"`
+	StatementTimeout types.String `tfsdk:"statement_timeout"`
+}
+
+// roleAttributes is the sql side of a roleAttributesModel, nil fields are not
+// managed.
+type roleAttributes struct {
+	ConnectionLimit  *int64
+	Login            *bool
+	CreateDB         *bool
+	CreateRole       *bool
+	InRoles          []string
+	SearchPath       []string
+	StatementTimeout *string
+}
+
+func roleAttributesResourceAttr() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"connection_limit": schema.Int64Attribute{
+			MarkdownDescription: "maximum concurrent connections, -1 for no limit",
+			Optional:            true,
+			Validators:          []validator.Int64{int64validator.AtLeast(-1)},
+		},
+		"login": schema.BoolAttribute{
+			MarkdownDescription: "whether the role can log in",
+			Optional:            true,
+		},
+		"create_db": schema.BoolAttribute{
+			MarkdownDescription: "whether the role can create databases",
+			Optional:            true,
+		},
+		"create_role": schema.BoolAttribute{
+			MarkdownDescription: "whether the role can create roles",
+			Optional:            true,
+		},
+		"in_roles": schema.SetAttribute{
+			MarkdownDescription: "roles this role is a member of, memberships not listed here are left untouched",
+			ElementType:         types.StringType,
+			Optional:            true,
+		},
+		"search_path": schema.ListAttribute{
+			MarkdownDescription: "schemas of the role search_path",
+			ElementType:         types.StringType,
+			Optional:            true,
+		},
+		"statement_timeout": schema.StringAttribute{
+			MarkdownDescription: "role statement_timeout, e.g. 30s",
+			Optional:            true,
+		},
+	}
+}
+
+func (m *roleAttributesModel) isSet() bool {
+	return !m.ConnectionLimit.IsNull() || !m.Login.IsNull() || !m.CreateDB.IsNull() || !m.CreateRole.IsNull() ||
+		!m.InRoles.IsNull() || !m.SearchPath.IsNull() || !m.StatementTimeout.IsNull()
+}
+
+func (m *roleAttributesModel) toRoleAttributes(ctx context.Context) (*roleAttributes, error) {
+	a := &roleAttributes{
+		ConnectionLimit:  m.ConnectionLimit.ValueInt64Pointer(),
+		Login:            m.Login.ValueBoolPointer(),
+		CreateDB:         m.CreateDB.ValueBoolPointer(),
+		CreateRole:       m.CreateRole.ValueBoolPointer(),
+		StatementTimeout: m.StatementTimeout.ValueStringPointer(),
+	}
+	if !m.InRoles.IsNull() {
+		a.InRoles = []string{}
+		if diags := m.InRoles.ElementsAs(ctx, &a.InRoles, false); diags.HasError() {
+			return nil, fmt.Errorf("cannot read in_roles")
+		}
+		sort.Strings(a.InRoles)
+	}
+	if !m.SearchPath.IsNull() {
+		a.SearchPath = []string{}
+		if diags := m.SearchPath.ElementsAs(ctx, &a.SearchPath, false); diags.HasError() {
+			return nil, fmt.Errorf("cannot read search_path")
+		}
+	}
+	return a, nil
+}
+
+func boolOption(value *bool, on, off string) string {
+	if *value {
+		return on
+	}
+	return off
+}
+
+func quoteIdentifiers(names []string) string {
+	quoted := []string{}
+	for _, v := range names {
+		quoted = append(quoted, pgx.Identifier{v}.Sanitize())
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// quoteLiteral quotes a string constant, assuming standard_conforming_strings.
+func quoteLiteral(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// alterStatements returns the statements moving the role from the attributes
+// in state, which may be nil, to a.
+func (a *roleAttributes) alterStatements(role string, state *roleAttributes) []string {
+	if state == nil {
+		state = &roleAttributes{}
+	}
+	name := pgx.Identifier{role}.Sanitize()
+	statements := []string{}
+
+	options := []string{}
+	if a.Login != nil {
+		options = append(options, boolOption(a.Login, "LOGIN", "NOLOGIN"))
+	}
+	if a.CreateDB != nil {
+		options = append(options, boolOption(a.CreateDB, "CREATEDB", "NOCREATEDB"))
+	}
+	if a.CreateRole != nil {
+		options = append(options, boolOption(a.CreateRole, "CREATEROLE", "NOCREATEROLE"))
+	}
+	if a.ConnectionLimit != nil {
+		options = append(options, fmt.Sprintf("CONNECTION LIMIT %d", *a.ConnectionLimit))
+	}
+	if len(options) != 0 {
+		statements = append(statements, fmt.Sprintf("ALTER ROLE %s WITH %s", name, strings.Join(options, " ")))
+	}
+
+	if a.SearchPath != nil && len(a.SearchPath) != 0 {
+		statements = append(statements, fmt.Sprintf("ALTER ROLE %s SET search_path TO %s", name, quoteIdentifiers(a.SearchPath)))
+	} else if state.SearchPath != nil {
+		statements = append(statements, fmt.Sprintf("ALTER ROLE %s RESET search_path", name))
+	}
+	if a.StatementTimeout != nil {
+		statements = append(statements, fmt.Sprintf("ALTER ROLE %s SET statement_timeout TO %s", name, quoteLiteral(*a.StatementTimeout)))
+	} else if state.StatementTimeout != nil {
+		statements = append(statements, fmt.Sprintf("ALTER ROLE %s RESET statement_timeout", name))
+	}
+
+	grant := []string{}
+	for _, v := range a.InRoles {
+		if !containsString(state.InRoles, v) {
+			grant = append(grant, v)
+		}
+	}
+	revoke := []string{}
+	for _, v := range state.InRoles {
+		if !containsString(a.InRoles, v) {
+			revoke = append(revoke, v)
+		}
+	}
+	if len(grant) != 0 {
+		statements = append(statements, fmt.Sprintf("GRANT %s TO %s", quoteIdentifiers(grant), name))
+	}
+	if len(revoke) != 0 {
+		statements = append(statements, fmt.Sprintf("REVOKE %s FROM %s", quoteIdentifiers(revoke), name))
+	}
+	return statements
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// parseRoleConfig splits pg_roles.rolconfig entries into settings.
+func parseRoleConfig(config []string) map[string]string {
+	settings := map[string]string{}
+	for _, v := range config {
+		key, value, found := strings.Cut(v, "=")
+		if found {
+			settings[key] = value
+		}
+	}
+	return settings
+}
+
+// parseSearchPath splits a search_path setting into schema names.
+func parseSearchPath(value string) []string {
+	schemas := []string{}
+	for _, v := range strings.Split(value, ",") {
+		v = strings.TrimSpace(v)
+		if strings.HasPrefix(v, `"`) && strings.HasSuffix(v, `"`) && len(v) > 1 {
+			v = strings.ReplaceAll(v[1:len(v)-1], `""`, `"`)
+		}
+		if v != "" {
+			schemas = append(schemas, v)
+		}
+	}
+	return schemas
+}
+
+// read refreshes the attributes set in m from the catalogs.
+func (m *roleAttributesModel) read(ctx context.Context, conn *pgx.Conn, role string) error {
+	var login, createDB, createRole bool
+	var connectionLimit int64
+	var config []string
+	err := conn.QueryRow(ctx, `SELECT rolcanlogin, rolcreatedb, rolcreaterole, rolconnlimit, coalesce(rolconfig, '{}')
+FROM pg_roles WHERE rolname = $1`, role).Scan(&login, &createDB, &createRole, &connectionLimit, &config)
+	if err != nil {
+		return err
+	}
+	if !m.Login.IsNull() {
+		m.Login = types.BoolValue(login)
+	}
+	if !m.CreateDB.IsNull() {
+		m.CreateDB = types.BoolValue(createDB)
+	}
+	if !m.CreateRole.IsNull() {
+		m.CreateRole = types.BoolValue(createRole)
+	}
+	if !m.ConnectionLimit.IsNull() {
+		m.ConnectionLimit = types.Int64Value(connectionLimit)
+	}
+	settings := parseRoleConfig(config)
+	if !m.SearchPath.IsNull() {
+		searchPath, diags := types.ListValueFrom(ctx, types.StringType, parseSearchPath(settings["search_path"]))
+		if diags.HasError() {
+			return fmt.Errorf("cannot set search_path")
+		}
+		m.SearchPath = searchPath
+	}
+	if !m.StatementTimeout.IsNull() {
+		if value, ok := settings["statement_timeout"]; ok {
+			m.StatementTimeout = types.StringValue(value)
+		} else {
+			m.StatementTimeout = types.StringValue("")
+		}
+	}
+	if !m.InRoles.IsNull() {
+		rows, err := conn.Query(ctx, `SELECT g.rolname
+FROM pg_auth_members a JOIN pg_roles g ON g.oid = a.roleid JOIN pg_roles u ON u.oid = a.member
+WHERE u.rolname = $1`, role)
+		if err != nil {
+			return err
+		}
+		memberships, err := pgx.CollectRows(rows, pgx.RowTo[string])
+		if err != nil {
+			return err
+		}
+		configured := []string{}
+		if diags := m.InRoles.ElementsAs(ctx, &configured, false); diags.HasError() {
+			return fmt.Errorf("cannot read in_roles")
+		}
+		inRoles := []string{}
+		for _, v := range configured {
+			if containsString(memberships, v) {
+				inRoles = append(inRoles, v)
+			}
+		}
+		aux, diags := types.SetValueFrom(ctx, types.StringType, inRoles)
+		if diags.HasError() {
+			return fmt.Errorf("cannot set in_roles")
+		}
+		m.InRoles = aux
+	}
+	return nil
+}
+
+// branchDatabase returns the name of the first database of a branch, used to
+// connect when the statements are not tied to a database.
+func branchDatabase(client *resty.Client, projectID, branchID string) (string, error) {
+	response, err := client.R().Get(fmt.Sprintf("/projects/%s/branches/%s/databases", projectID, branchID))
+	if err != nil {
+		return "", err
+	}
+	if response.IsError() {
+		return "", fmt.Errorf("failed to list branch databases with a status code: %s", response.Status())
+	}
+	inner := struct {
+		Databases []databaseResourceJSON `json:"databases"`
+	}{}
+	err = json.Unmarshal(response.Body(), &inner)
+	if err != nil {
+		return "", err
+	}
+	if len(inner.Databases) == 0 {
+		return "", fmt.Errorf("branch %s has no database to connect to", branchID)
+	}
+	return inner.Databases[0].Name, nil
+}
+
+// openBranchDatabase connects to the first database of a branch.
+func openBranchDatabase(ctx context.Context, client *resty.Client, projectID, branchID string) (*pgx.Conn, error) {
+	databaseName, err := branchDatabase(client, projectID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	return openDatabase(ctx, client, projectID, branchID, databaseName)
+}
+
+// applyRoleAttributes runs the statements moving the role from state to plan
+// in a single transaction.
+func applyRoleAttributes(ctx context.Context, conn *pgx.Conn, role string, plan, state *roleAttributes) error {
+	statements := plan.alterStatements(role, state)
+	if len(statements) == 0 {
+		return nil
+	}
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	for _, v := range statements {
+		if _, err := tx.Exec(ctx, v); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
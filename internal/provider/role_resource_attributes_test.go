@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestRoleAttributesAlterStatements(t *testing.T) {
+	on, off := true, false
+	limit := int64(5)
+	timeout := "30s"
+	tests := []struct {
+		name  string
+		plan  roleAttributes
+		state *roleAttributes
+		want  []string
+	}{
+		{
+			name: "nothing set",
+			want: []string{},
+		},
+		{
+			name: "create",
+			plan: roleAttributes{
+				Login:            &on,
+				CreateDB:         &off,
+				ConnectionLimit:  &limit,
+				SearchPath:       []string{"app", "public"},
+				StatementTimeout: &timeout,
+				InRoles:          []string{"readers"},
+			},
+			want: []string{
+				`ALTER ROLE "app_user" WITH LOGIN NOCREATEDB CONNECTION LIMIT 5`,
+				`ALTER ROLE "app_user" SET search_path TO "app", "public"`,
+				`ALTER ROLE "app_user" SET statement_timeout TO '30s'`,
+				`GRANT "readers" TO "app_user"`,
+			},
+		},
+		{
+			name: "update",
+			plan: roleAttributes{
+				CreateRole: &on,
+				InRoles:    []string{"writers"},
+			},
+			state: &roleAttributes{
+				SearchPath:       []string{"app"},
+				StatementTimeout: &timeout,
+				InRoles:          []string{"readers"},
+			},
+			want: []string{
+				`ALTER ROLE "app_user" WITH CREATEROLE`,
+				`ALTER ROLE "app_user" RESET search_path`,
+				`ALTER ROLE "app_user" RESET statement_timeout`,
+				`GRANT "writers" TO "app_user"`,
+				`REVOKE "readers" FROM "app_user"`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.plan.alterStatements("app_user", tt.state); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("alterStatements() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSearchPath(t *testing.T) {
+	tests := map[string][]string{
+		"":                        {},
+		`"$user", public`:         {"$user", "public"},
+		`app, "My ""Schema""", x`: {"app", `My "Schema"`, "x"},
+	}
+	for in, want := range tests {
+		if got := parseSearchPath(in); !reflect.DeepEqual(got, want) {
+			t.Errorf("parseSearchPath(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestRoleResourceWithAttributesModel(t *testing.T) {
+	ctx := context.Background()
+	resp := &resource.SchemaResponse{}
+	roleResource{}.Schema(ctx, resource.SchemaRequest{}, resp)
+
+	state := tfsdk.State{
+		Schema: resp.Schema,
+		Raw:    tftypes.NewValue(resp.Schema.Type().TerraformType(ctx), nil),
+	}
+	in := roleResourceWithAttributesModel{
+		roleResourceModel: roleResourceModel{
+			Name:                    types.StringValue("app_user"),
+			PasswordRotationTrigger: types.MapNull(types.StringType),
+		},
+		roleAttributesModel: roleAttributesModel{
+			Login:      types.BoolValue(true),
+			InRoles:    types.SetNull(types.StringType),
+			SearchPath: types.ListNull(types.StringType),
+		},
+	}
+	if diags := state.Set(ctx, &in); diags.HasError() {
+		t.Fatalf("Set() = %v", diags)
+	}
+	var out roleResourceWithAttributesModel
+	if diags := state.Get(ctx, &out); diags.HasError() {
+		t.Fatalf("Get() = %v", diags)
+	}
+	if !out.Name.Equal(in.Name) || !out.Login.Equal(in.Login) || out.CreateDB.ValueBool() {
+		t.Errorf("Get() = %+v, want %+v", out, in)
+	}
+}
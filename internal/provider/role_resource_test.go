@@ -20,6 +20,7 @@ func TestRoleResource(t *testing.T) {
 					resource.TestCheckResourceAttr("neon_project.test", "name", "name_project"),
 					resource.TestCheckResourceAttr("neon_branch.test", "name", "name_branch"),
 					resource.TestCheckResourceAttr("neon_role.test", "name", "name_role"),
+					resource.TestCheckResourceAttrSet("neon_role.test", "password"),
 				),
 			},
 			// ImportState testing
@@ -34,6 +35,33 @@ func TestRoleResource(t *testing.T) {
 					return "", fmt.Errorf("cannot find neon_role.test")
 				},
 			},
+			// Password rotation testing
+			{
+				Config: testRoleResourceRotate(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_role.test", "password_rotation_trigger.rotated", "1"),
+					resource.TestCheckResourceAttrSet("neon_role.test", "password"),
+				),
+			},
+			// SQL attributes testing
+			{
+				Config: testRoleResourceAttributes(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_role.test", "connection_limit", "5"),
+					resource.TestCheckResourceAttr("neon_role.test", "create_db", "true"),
+					resource.TestCheckResourceAttr("neon_role.test", "search_path.#", "2"),
+					resource.TestCheckResourceAttr("neon_role.test", "statement_timeout", "30s"),
+				),
+			},
+			// Replace testing
+			{
+				Config: testRoleResourceRenamed(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_role.test", "name", "name_role_renamed"),
+					resource.TestCheckResourceAttr("neon_role.test", "id", "name_role_renamed"),
+					resource.TestCheckResourceAttrSet("neon_role.test", "password"),
+				),
+			},
 		},
 	})
 }
@@ -47,13 +75,13 @@ resource "neon_project" "test" {
 resource "neon_branch" "test" {
 	project_id = neon_project.test.id
 	name = "name_branch"
-	endpoints = [
-		{
+	endpoints = {
+		primary = {
 			type = "read_write"
 			autoscaling_limit_min_cu = 1
 			autoscaling_limit_max_cu = 1
 		}
-	]
+	}
 }
 
 resource "neon_role" "test" {
@@ -63,3 +91,91 @@ resource "neon_role" "test" {
 }
 `
 }
+
+func testRoleResourceRotate() string {
+	return `
+resource "neon_project" "test" {
+	name = "name_project"
+}
+
+resource "neon_branch" "test" {
+	project_id = neon_project.test.id
+	name = "name_branch"
+	endpoints = {
+		primary = {
+			type = "read_write"
+			autoscaling_limit_min_cu = 1
+			autoscaling_limit_max_cu = 1
+		}
+	}
+}
+
+resource "neon_role" "test" {
+	project_id = neon_project.test.id
+	branch_id = neon_branch.test.id
+	name = "name_role"
+	password_rotation_trigger = {
+		rotated = "1"
+	}
+}
+`
+}
+
+func testRoleResourceAttributes() string {
+	return `
+resource "neon_project" "test" {
+	name = "name_project"
+}
+
+resource "neon_branch" "test" {
+	project_id = neon_project.test.id
+	name = "name_branch"
+	endpoints = {
+		primary = {
+			type = "read_write"
+			autoscaling_limit_min_cu = 1
+			autoscaling_limit_max_cu = 1
+		}
+	}
+}
+
+resource "neon_role" "test" {
+	project_id = neon_project.test.id
+	branch_id = neon_branch.test.id
+	name = "name_role"
+	password_rotation_trigger = {
+		rotated = "1"
+	}
+	connection_limit = 5
+	create_db = true
+	search_path = ["app", "public"]
+	statement_timeout = "30s"
+}
+`
+}
+
+func testRoleResourceRenamed() string {
+	return `
+resource "neon_project" "test" {
+	name = "name_project"
+}
+
+resource "neon_branch" "test" {
+	project_id = neon_project.test.id
+	name = "name_branch"
+	endpoints = {
+		primary = {
+			type = "read_write"
+			autoscaling_limit_min_cu = 1
+			autoscaling_limit_max_cu = 1
+		}
+	}
+}
+
+resource "neon_role" "test" {
+	project_id = neon_project.test.id
+	branch_id = neon_branch.test.id
+	name = "name_role_renamed"
+}
+`
+}
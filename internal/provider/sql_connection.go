@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// tableNameRegex matches schema qualified table names, e.g. public.users.
+var tableNameRegex = regexp.MustCompile(`^[^.]+\.[^.]+$`)
+
+// branchEndpointHost returns the host of the read_write endpoint of a branch.
+func branchEndpointHost(client *resty.Client, projectID, branchID string) (string, error) {
+	response, err := client.R().Get(fmt.Sprintf("/projects/%s/branches/%s/endpoints", projectID, branchID))
+	if err != nil {
+		return "", err
+	}
+	if response.IsError() {
+		return "", fmt.Errorf("failed to list branch endpoints with a status code: %s", response.Status())
+	}
+	inner := struct {
+		Endpoints []endpointResourceJSON `json:"endpoints"`
+	}{}
+	err = json.Unmarshal(response.Body(), &inner)
+	if err != nil {
+		return "", err
+	}
+	for _, v := range inner.Endpoints {
+		if v.Type == "read_write" {
+			return v.Host, nil
+		}
+	}
+	return "", fmt.Errorf("branch %s has no read_write endpoint", branchID)
+}
+
+// databaseOwner returns the name of the role owning a database.
+func databaseOwner(client *resty.Client, projectID, branchID, databaseName string) (string, error) {
+	response, err := client.R().Get(fmt.Sprintf("/projects/%s/branches/%s/databases/%s", projectID, branchID, databaseName))
+	if err != nil {
+		return "", err
+	}
+	if response.IsError() {
+		return "", fmt.Errorf("failed to get database with a status code: %s", response.Status())
+	}
+	inner := struct {
+		Database databaseResourceJSON `json:"database"`
+	}{}
+	err = json.Unmarshal(response.Body(), &inner)
+	if err != nil {
+		return "", err
+	}
+	return inner.Database.OwnerName, nil
+}
+
+// openDatabase connects to a database of a branch through its read_write
+// endpoint, as the role owning the database. Tests replace it to run against
+// a local postgres.
+var openDatabase = func(ctx context.Context, client *resty.Client, projectID, branchID, databaseName string) (*pgx.Conn, error) {
+	host, err := branchEndpointHost(client, projectID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	owner, err := databaseOwner(client, projectID, branchID, databaseName)
+	if err != nil {
+		return nil, err
+	}
+	password, err := revealRolePassword(client, projectID, branchID, owner)
+	if err != nil {
+		return nil, err
+	}
+	dsn := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(owner, password),
+		Host:     host,
+		Path:     "/" + databaseName,
+		RawQuery: "sslmode=require",
+	}
+	conn, err := pgx.Connect(ctx, dsn.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database %s on %s: %s", databaseName, host, connectErrorMessage(err, password))
+	}
+	return conn, nil
+}
+
+// connectErrorMessage describes why a connection failed, e.g. an
+// authentication failure, a tls error or a timeout. pgx errors may contain the
+// dsn, and with it the password, which is redacted.
+func connectErrorMessage(err error, password string) string {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return fmt.Sprintf("%s (SQLSTATE %s)", pgErr.Message, pgErr.Code)
+	}
+	message := err.Error()
+	var connectErr *pgconn.ConnectError
+	if errors.As(err, &connectErr) && errors.Unwrap(connectErr) != nil {
+		message = errors.Unwrap(connectErr).Error()
+	}
+	if password != "" {
+		message = strings.NewReplacer(password, "xxxxx", url.QueryEscape(password), "xxxxx", url.PathEscape(password), "xxxxx").Replace(message)
+	}
+	return message
+}
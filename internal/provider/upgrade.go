@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// upgradeFromV0 upgrades the states recorded before the schemas declared a
+// version. Attributes added since are null until the next read and the ones
+// removed are dropped, which also holds for later versions only adding
+// attributes or turning lists into sets.
+func upgradeFromV0(r resource.Resource) resource.StateUpgrader {
+	return upgradeRawState(r, nil)
+}
+
+// upgradeRawState reads the recorded state with the current schema, once
+// rewrite, when set, moved the recorded attributes to their current place.
+func upgradeRawState(r resource.Resource, rewrite func(state map[string]interface{})) resource.StateUpgrader {
+	return resource.StateUpgrader{
+		StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+			current := resource.SchemaResponse{}
+			r.Schema(ctx, resource.SchemaRequest{}, &current)
+			rawState := req.RawState
+			if rewrite != nil {
+				var err error
+				rawState, err = rewriteRawState(rawState, rewrite)
+				if err != nil {
+					resp.Diagnostics.AddError("Unable to upgrade state", err.Error())
+					return
+				}
+			}
+			value, err := rawState.UnmarshalWithOpts(current.Schema.Type().TerraformType(ctx), tfprotov6.UnmarshalOpts{
+				ValueFromJSONOpts: tftypes.ValueFromJSONOpts{
+					IgnoreUndefinedAttributes: true,
+				},
+			})
+			if err != nil {
+				resp.Diagnostics.AddError("Unable to upgrade state", err.Error())
+				return
+			}
+			resp.State = tfsdk.State{
+				Schema: current.Schema,
+				Raw:    value,
+			}
+		},
+	}
+}
+
+func rewriteRawState(rawState *tfprotov6.RawState, rewrite func(state map[string]interface{})) (*tfprotov6.RawState, error) {
+	if rawState == nil || rawState.JSON == nil {
+		return nil, fmt.Errorf("the recorded state is not json")
+	}
+	state := map[string]interface{}{}
+	decoder := json.NewDecoder(bytes.NewReader(rawState.JSON))
+	decoder.UseNumber()
+	err := decoder.Decode(&state)
+	if err != nil {
+		return nil, err
+	}
+	rewrite(state)
+	recorded, err := json.Marshal(state)
+	if err != nil {
+		return nil, err
+	}
+	return &tfprotov6.RawState{JSON: recorded}, nil
+}
+
+// upgradeBranchEndpoints splits the endpoints list of branches recorded before
+// version 2 into the configured endpoints, labelled by their ids as on import,
+// and the computed endpoint_details.
+func upgradeBranchEndpoints(branch map[string]interface{}) {
+	endpoints, ok := branch["endpoints"].([]interface{})
+	if !ok {
+		return
+	}
+	details := []map[string]interface{}{}
+	configured := map[string]interface{}{}
+	for _, v := range endpoints {
+		endpoint, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		details = append(details, endpoint)
+		configured[fmt.Sprint(endpoint["id"])] = map[string]interface{}{
+			"id":                       endpoint["id"],
+			"type":                     endpoint["type"],
+			"autoscaling_limit_min_cu": endpoint["autoscaling_limit_min_cu"],
+			"autoscaling_limit_max_cu": endpoint["autoscaling_limit_max_cu"],
+		}
+	}
+	sort.SliceStable(details, func(i, j int) bool {
+		if fmt.Sprint(details[i]["type"]) != fmt.Sprint(details[j]["type"]) {
+			return fmt.Sprint(details[i]["type"]) < fmt.Sprint(details[j]["type"])
+		}
+		return fmt.Sprint(details[i]["id"]) < fmt.Sprint(details[j]["id"])
+	})
+	branch["endpoints"] = configured
+	branch["endpoint_details"] = details
+}
@@ -0,0 +1,190 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// TestUpgradeStateFromV0 upgrades the states recorded in testdata/state_v0,
+// before the schemas declared a version, through the provider server and
+// reads them back into the current models.
+func TestUpgradeStateFromV0(t *testing.T) {
+	ctx := context.Background()
+	models := map[string]func() interface{}{
+		"api_key":                  func() interface{} { return &apiKeyResourceModel{} },
+		"branch":                   func() interface{} { return &BranchResourceModel{} },
+		"database":                 func() interface{} { return &databaseResourceModel{} },
+		"endpoint":                 func() interface{} { return &endpointResourceModel{} },
+		"extension":                func() interface{} { return &extensionResourceModel{} },
+		"grant":                    func() interface{} { return &grantResourceModel{} },
+		"project":                  func() interface{} { m := newProjectResourceModel(); return &m },
+		"project_permission":       func() interface{} { return &projectPermissionResourceModel{} },
+		"publication":              func() interface{} { return &publicationResourceModel{} },
+		"role":                     func() interface{} { return &roleResourceWithAttributesModel{} },
+		"vpc_endpoint":             func() interface{} { return &vpcEndpointResourceModel{} },
+		"vpc_endpoint_restriction": func() interface{} { return &vpcEndpointRestrictionResourceModel{} },
+	}
+
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatalf("NewProtocol6WithError() error = %s", err)
+	}
+	schemas := map[string]resource.SchemaResponse{}
+	for _, factory := range New("test")().Resources(ctx) {
+		r := factory()
+		metadata := resource.MetadataResponse{}
+		r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "neon"}, &metadata)
+		schema := resource.SchemaResponse{}
+		r.Schema(ctx, resource.SchemaRequest{}, &schema)
+		schemas[metadata.TypeName] = schema
+	}
+
+	for name := range models {
+		if _, ok := schemas["neon_"+name]; !ok {
+			t.Errorf("neon_%s is not a resource", name)
+		}
+	}
+
+	// resources added after the schemas were versioned have no state to
+	// upgrade, they keep version 0 until their schema changes
+	for typeName := range schemas {
+		name := strings.TrimPrefix(typeName, "neon_")
+		model, ok := models[name]
+		if !ok {
+			if schemas[typeName].Schema.Version != 0 {
+				t.Errorf("%s schema version = %d without a recorded state to upgrade", typeName, schemas[typeName].Schema.Version)
+			}
+			continue
+		}
+		version := int64(1)
+		if name == "branch" || name == "project" {
+			version = 2
+		}
+		if schemas[typeName].Schema.Version != version {
+			t.Errorf("%s schema version = %d, want %d", typeName, schemas[typeName].Schema.Version, version)
+		}
+		recorded, err := os.ReadFile(filepath.Join("testdata", "state_v0", name+".json"))
+		if err != nil {
+			t.Errorf("%s fixture: %s", typeName, err)
+			continue
+		}
+
+		resp, err := server.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+			TypeName: typeName,
+			Version:  0,
+			RawState: &tfprotov6.RawState{JSON: recorded},
+		})
+		if err != nil {
+			t.Errorf("%s UpgradeResourceState() error = %s", typeName, err)
+			continue
+		}
+		for _, v := range resp.Diagnostics {
+			t.Errorf("%s UpgradeResourceState() diagnostic: %s: %s", typeName, v.Summary, v.Detail)
+		}
+		if resp.UpgradedState == nil {
+			continue
+		}
+
+		schema := schemas[typeName].Schema
+		value, err := resp.UpgradedState.Unmarshal(schema.Type().TerraformType(ctx))
+		if err != nil {
+			t.Errorf("%s upgraded state: %s", typeName, err)
+			continue
+		}
+		state := tfsdk.State{Schema: schema, Raw: value}
+		diags := state.Get(ctx, model())
+		if diags.HasError() {
+			t.Errorf("%s upgraded state into the model: %v", typeName, diags)
+		}
+		attributes := map[string]tftypes.Value{}
+		if err := value.As(&attributes); err != nil || attributes["id"].IsNull() {
+			t.Errorf("%s upgraded state lost its id", typeName)
+		}
+	}
+}
+
+// TestUpgradeBranchEndpoints upgrades a branch recorded with its endpoints as
+// a list and checks they are split into the configured endpoints labelled by
+// their ids and the sorted endpoint details.
+func TestUpgradeBranchEndpoints(t *testing.T) {
+	ctx := context.Background()
+	recorded, err := os.ReadFile(filepath.Join("testdata", "state_v0", "branch.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := map[string]interface{}{}
+	if err := json.Unmarshal(recorded, &state); err != nil {
+		t.Fatal(err)
+	}
+	readWrite := state["endpoints"].([]interface{})[0].(map[string]interface{})
+	readOnly := map[string]interface{}{}
+	for k, v := range readWrite {
+		readOnly[k] = v
+	}
+	readOnly["id"] = "ep-quiet-lake-654321"
+	readOnly["type"] = "read_only"
+	readOnly["autoscaling_limit_max_cu"] = 2
+	state["endpoints"] = []interface{}{readWrite, readOnly}
+	recorded, err = json.Marshal(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatalf("NewProtocol6WithError() error = %s", err)
+	}
+	resp, err := server.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+		TypeName: "neon_branch",
+		Version:  1,
+		RawState: &tfprotov6.RawState{JSON: recorded},
+	})
+	if err != nil {
+		t.Fatalf("UpgradeResourceState() error = %s", err)
+	}
+	for _, v := range resp.Diagnostics {
+		t.Fatalf("UpgradeResourceState() diagnostic: %s: %s", v.Summary, v.Detail)
+	}
+
+	schema := resource.SchemaResponse{}
+	branchResource{}.Schema(ctx, resource.SchemaRequest{}, &schema)
+	value, err := resp.UpgradedState.Unmarshal(schema.Schema.Type().TerraformType(ctx))
+	if err != nil {
+		t.Fatalf("upgraded state: %s", err)
+	}
+	m := BranchResourceModel{}
+	diags := (&tfsdk.State{Schema: schema.Schema, Raw: value}).Get(ctx, &m)
+	if diags.HasError() {
+		t.Fatalf("upgraded state into the model: %v", diags)
+	}
+
+	endpoints := map[string]branchResourceEndpointModel{}
+	diags = m.Endpoints.ElementsAs(ctx, &endpoints, false)
+	if diags.HasError() || len(endpoints) != 2 {
+		t.Fatalf("endpoints = %s, want two endpoints", m.Endpoints)
+	}
+	if endpoints["ep-quiet-lake-654321"].Type.ValueString() != "read_only" || endpoints["ep-cool-darkness-123456"].ID.ValueString() != "ep-cool-darkness-123456" {
+		t.Errorf("endpoints = %+v, want them labelled by their ids", endpoints)
+	}
+	details := []endpointResourceModel{}
+	diags = m.EndpointDetails.ElementsAs(ctx, &details, false)
+	if diags.HasError() || len(details) != 2 {
+		t.Fatalf("endpoint_details = %s, want two endpoints", m.EndpointDetails)
+	}
+	if details[0].Id.ValueString() != "ep-quiet-lake-654321" || details[1].Id.ValueString() != "ep-cool-darkness-123456" {
+		t.Errorf("endpoint_details ids = %s, %s, want the read_only endpoint first", details[0].Id, details[1].Id)
+	}
+	if details[0].AutoscalingLimitMaxCu.ValueInt64() != 2 || details[1].Host.ValueString() == "" {
+		t.Errorf("endpoint_details = %+v, want the recorded endpoints", details)
+	}
+}
@@ -0,0 +1,257 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type vpcEndpointResource struct {
+	client       *resty.Client
+	defaultOrgID string
+}
+type vpcEndpointJSON struct {
+	VpcEndpointID         string `json:"vpc_endpoint_id"`
+	Label                 string `json:"label"`
+	State                 string `json:"state"`
+	NumRestrictedProjects int64  `json:"num_restricted_projects"`
+}
+type vpcEndpointResourceModel struct {
+	ID                    types.String `tfsdk:"id"`
+	OrgID                 types.String `tfsdk:"org_id"`
+	RegionID              types.String `tfsdk:"region_id"`
+	VpcEndpointID         types.String `tfsdk:"vpc_endpoint_id"`
+	Label                 types.String `tfsdk:"label"`
+	State                 types.String `tfsdk:"state"`
+	NumRestrictedProjects types.Int64  `tfsdk:"num_restricted_projects"`
+}
+
+var _ resource.Resource = vpcEndpointResource{}
+var _ resource.ResourceWithUpgradeState = vpcEndpointResource{}
+var _ resource.ResourceWithImportState = vpcEndpointResource{}
+var _ resource.ResourceWithIdentity = vpcEndpointResource{}
+
+func (in *vpcEndpointJSON) toVpcEndpointResourceModel(orgID, regionID string) *vpcEndpointResourceModel {
+	return &vpcEndpointResourceModel{
+		ID:                    types.StringValue(in.VpcEndpointID),
+		OrgID:                 types.StringValue(orgID),
+		RegionID:              types.StringValue(regionID),
+		VpcEndpointID:         types.StringValue(in.VpcEndpointID),
+		Label:                 types.StringValue(in.Label),
+		State:                 types.StringValue(in.State),
+		NumRestrictedProjects: types.Int64Value(in.NumRestrictedProjects),
+	}
+}
+
+func vpcEndpointURL(orgID, regionID, vpcEndpointID string) string {
+	return fmt.Sprintf("/organizations/%s/vpc/region/%s/vpc_endpoints/%s", orgID, regionID, vpcEndpointID)
+}
+
+// assignVpcEndpoint registers a vpc endpoint with an organization, or updates
+// its label when it is already registered.
+func assignVpcEndpoint(client *resty.Client, orgID, regionID, vpcEndpointID, label string) error {
+	content := struct {
+		Label string `json:"label"`
+	}{
+		Label: label,
+	}
+	response, err := client.R().
+		SetBody(content).
+		Post(vpcEndpointURL(orgID, regionID, vpcEndpointID))
+	if err != nil {
+		return err
+	}
+	if response.IsError() {
+		return fmt.Errorf("failed to assign vpc endpoint with a status code: %s", response.Status())
+	}
+	return nil
+}
+
+// getVpcEndpoint returns nil when the vpc endpoint is not registered with the
+// organization.
+func getVpcEndpoint(client *resty.Client, orgID, regionID, vpcEndpointID string) (*vpcEndpointJSON, error) {
+	response, err := client.R().Get(vpcEndpointURL(orgID, regionID, vpcEndpointID))
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode() == http.StatusNotFound {
+		return nil, nil
+	}
+	if response.IsError() {
+		return nil, fmt.Errorf("failed to get vpc endpoint with a status code: %s", response.Status())
+	}
+	endpoint := &vpcEndpointJSON{}
+	err = json.Unmarshal(response.Body(), endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return endpoint, nil
+}
+
+func (r vpcEndpointResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vpc_endpoint"
+}
+
+func (r vpcEndpointResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version:             1,
+		MarkdownDescription: "Neon vpc endpoint resource, registers an aws vpc endpoint with an organization",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "vpc endpoint id",
+				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"org_id": schema.StringAttribute{
+				MarkdownDescription: "organization id, defaults to the provider default_org_id",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"region_id": schema.StringAttribute{
+				MarkdownDescription: "region id",
+				Required:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"vpc_endpoint_id": schema.StringAttribute{
+				MarkdownDescription: "aws vpc endpoint id, e.g. vpce-0123456789abcdef0",
+				Required:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"label": schema.StringAttribute{
+				MarkdownDescription: "vpc endpoint label",
+				Required:            true,
+			},
+			"state": schema.StringAttribute{
+				MarkdownDescription: "vpc endpoint state",
+				Computed:            true,
+			},
+			"num_restricted_projects": schema.Int64Attribute{
+				MarkdownDescription: "number of projects restricted to this vpc endpoint",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r vpcEndpointResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: upgradeFromV0(r),
+	}
+}
+
+func (r vpcEndpointResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data vpcEndpointResourceModel
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	orgID := r.defaultOrgID
+	if !data.OrgID.IsUnknown() && !data.OrgID.IsNull() {
+		orgID = data.OrgID.ValueString()
+	}
+	if orgID == "" {
+		resp.Diagnostics.AddAttributeError(path.Root("org_id"), "Missing org_id", "Set org_id or the provider default_org_id")
+		return
+	}
+	err := assignVpcEndpoint(r.client, orgID, data.RegionID.ValueString(), data.VpcEndpointID.ValueString(), data.Label.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create vpc endpoint resource", err.Error())
+		return
+	}
+	endpoint, err := getVpcEndpoint(r.client, orgID, data.RegionID.ValueString(), data.VpcEndpointID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read vpc endpoint resource", err.Error())
+		return
+	}
+	if endpoint == nil {
+		resp.Diagnostics.AddError("Failed to read vpc endpoint resource", "vpc endpoint not found after creation")
+		return
+	}
+	diags = resp.State.Set(ctx, endpoint.toVpcEndpointResourceModel(orgID, data.RegionID.ValueString()))
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.State, resp.Identity)...)
+}
+
+func (r vpcEndpointResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data vpcEndpointResourceModel
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	endpoint, err := getVpcEndpoint(r.client, data.OrgID.ValueString(), data.RegionID.ValueString(), data.VpcEndpointID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read vpc endpoint resource", err.Error())
+		return
+	}
+	if endpoint == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	diags = resp.State.Set(ctx, endpoint.toVpcEndpointResourceModel(data.OrgID.ValueString(), data.RegionID.ValueString()))
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.State, resp.Identity)...)
+}
+
+func (r vpcEndpointResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data vpcEndpointResourceModel
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := assignVpcEndpoint(r.client, data.OrgID.ValueString(), data.RegionID.ValueString(), data.VpcEndpointID.ValueString(), data.Label.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to update vpc endpoint resource", err.Error())
+		return
+	}
+	endpoint, err := getVpcEndpoint(r.client, data.OrgID.ValueString(), data.RegionID.ValueString(), data.VpcEndpointID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read vpc endpoint resource", err.Error())
+		return
+	}
+	if endpoint == nil {
+		resp.Diagnostics.AddError("Failed to read vpc endpoint resource", "vpc endpoint not found after update")
+		return
+	}
+	diags = resp.State.Set(ctx, endpoint.toVpcEndpointResourceModel(data.OrgID.ValueString(), data.RegionID.ValueString()))
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.State, resp.Identity)...)
+}
+
+func (r vpcEndpointResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data vpcEndpointResourceModel
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	response, _ := r.client.R().Delete(vpcEndpointURL(data.OrgID.ValueString(), data.RegionID.ValueString(), data.VpcEndpointID.ValueString()))
+	if response.IsError() {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to delete vpc endpoint resource with a status code: %s", response.Status()), "")
+		return
+	}
+	resp.State.RemoveResource(ctx)
+}
+
+func (r vpcEndpointResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identitySchema("org_id", "region_id", "vpc_endpoint_id")
+}
+
+func (r vpcEndpointResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importState(ctx, req, resp, "vpc endpoint", "org_id/region_id/vpc_endpoint_id")
+}
@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestVpcEndpointResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckVpcEndpoint(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testVpcEndpointResource("label"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_vpc_endpoint.test", "vpc_endpoint_id", os.Getenv("NEON_VPC_ENDPOINT_ID")),
+					resource.TestCheckResourceAttr("neon_vpc_endpoint.test", "org_id", os.Getenv("NEON_ORG_ID")),
+					resource.TestCheckResourceAttr("neon_vpc_endpoint.test", "label", "label"),
+					resource.TestCheckResourceAttrSet("neon_vpc_endpoint.test", "state"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "neon_vpc_endpoint.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     fmt.Sprintf("%s/aws-us-east-2/%s", os.Getenv("NEON_ORG_ID"), os.Getenv("NEON_VPC_ENDPOINT_ID")),
+			},
+			// Update and Read testing
+			{
+				Config: testVpcEndpointResource("label_updated"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_vpc_endpoint.test", "label", "label_updated"),
+				),
+			},
+		},
+	})
+}
+
+func testVpcEndpointResource(label string) string {
+	return `
+resource "neon_vpc_endpoint" "test" {
+	org_id = "` + os.Getenv("NEON_ORG_ID") + `"
+	region_id = "aws-us-east-2"
+	vpc_endpoint_id = "` + os.Getenv("NEON_VPC_ENDPOINT_ID") + `"
+	label = "` + label + `"
+}
+`
+}
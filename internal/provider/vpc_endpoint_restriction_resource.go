@@ -0,0 +1,199 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type vpcEndpointRestrictionResource struct {
+	client *resty.Client
+}
+type vpcEndpointRestrictionJSON struct {
+	VpcEndpointID string `json:"vpc_endpoint_id"`
+	Label         string `json:"label"`
+}
+type vpcEndpointRestrictionResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	ProjectID     types.String `tfsdk:"project_id"`
+	VpcEndpointID types.String `tfsdk:"vpc_endpoint_id"`
+	Label         types.String `tfsdk:"label"`
+}
+
+var _ resource.Resource = vpcEndpointRestrictionResource{}
+var _ resource.ResourceWithUpgradeState = vpcEndpointRestrictionResource{}
+var _ resource.ResourceWithImportState = vpcEndpointRestrictionResource{}
+var _ resource.ResourceWithIdentity = vpcEndpointRestrictionResource{}
+
+func (in *vpcEndpointRestrictionJSON) toVpcEndpointRestrictionResourceModel(projectID string) *vpcEndpointRestrictionResourceModel {
+	return &vpcEndpointRestrictionResourceModel{
+		ID:            types.StringValue(in.VpcEndpointID),
+		ProjectID:     types.StringValue(projectID),
+		VpcEndpointID: types.StringValue(in.VpcEndpointID),
+		Label:         types.StringValue(in.Label),
+	}
+}
+
+func listVpcEndpointRestrictions(client *resty.Client, projectID string) ([]vpcEndpointRestrictionJSON, error) {
+	response, err := client.R().Get(fmt.Sprintf("/projects/%s/vpc_endpoints", projectID))
+	if err != nil {
+		return nil, err
+	}
+	if response.IsError() {
+		return nil, fmt.Errorf("failed to list vpc endpoint restrictions with a status code: %s", response.Status())
+	}
+	inner := struct {
+		Endpoints []vpcEndpointRestrictionJSON `json:"endpoints"`
+	}{}
+	err = json.Unmarshal(response.Body(), &inner)
+	if err != nil {
+		return nil, err
+	}
+	return inner.Endpoints, nil
+}
+
+// assignVpcEndpointRestriction restricts a project to a vpc endpoint, or
+// updates the label of an existing restriction.
+func assignVpcEndpointRestriction(client *resty.Client, projectID, vpcEndpointID, label string) error {
+	content := struct {
+		Label string `json:"label"`
+	}{
+		Label: label,
+	}
+	response, err := client.R().
+		SetBody(content).
+		Post(fmt.Sprintf("/projects/%s/vpc_endpoints/%s", projectID, vpcEndpointID))
+	if err != nil {
+		return err
+	}
+	if response.IsError() {
+		return fmt.Errorf("failed to assign vpc endpoint restriction with a status code: %s", response.Status())
+	}
+	return nil
+}
+
+func (r vpcEndpointRestrictionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vpc_endpoint_restriction"
+}
+
+func (r vpcEndpointRestrictionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version:             1,
+		MarkdownDescription: "Neon vpc endpoint restriction resource, attaches a vpc endpoint registered with neon_vpc_endpoint to a project",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "vpc endpoint id",
+				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "project id",
+				Required:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"vpc_endpoint_id": schema.StringAttribute{
+				MarkdownDescription: "aws vpc endpoint id",
+				Required:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"label": schema.StringAttribute{
+				MarkdownDescription: "restriction label",
+				Required:            true,
+			},
+		},
+	}
+}
+
+func (r vpcEndpointRestrictionResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: upgradeFromV0(r),
+	}
+}
+
+func (r vpcEndpointRestrictionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data vpcEndpointRestrictionResourceModel
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := assignVpcEndpointRestriction(r.client, data.ProjectID.ValueString(), data.VpcEndpointID.ValueString(), data.Label.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create vpc endpoint restriction resource", err.Error())
+		return
+	}
+	data.ID = data.VpcEndpointID
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.State, resp.Identity)...)
+}
+
+func (r vpcEndpointRestrictionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data vpcEndpointRestrictionResourceModel
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	restrictions, err := listVpcEndpointRestrictions(r.client, data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read vpc endpoint restriction resource", err.Error())
+		return
+	}
+	for _, v := range restrictions {
+		if v.VpcEndpointID == data.VpcEndpointID.ValueString() {
+			diags = resp.State.Set(ctx, v.toVpcEndpointRestrictionResourceModel(data.ProjectID.ValueString()))
+			resp.Diagnostics.Append(diags...)
+			resp.Diagnostics.Append(setIdentity(ctx, resp.State, resp.Identity)...)
+			return
+		}
+	}
+	resp.State.RemoveResource(ctx)
+}
+
+func (r vpcEndpointRestrictionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data vpcEndpointRestrictionResourceModel
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := assignVpcEndpointRestriction(r.client, data.ProjectID.ValueString(), data.VpcEndpointID.ValueString(), data.Label.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to update vpc endpoint restriction resource", err.Error())
+		return
+	}
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.State, resp.Identity)...)
+}
+
+func (r vpcEndpointRestrictionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data vpcEndpointRestrictionResourceModel
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	response, _ := r.client.R().Delete(fmt.Sprintf("/projects/%s/vpc_endpoints/%s", data.ProjectID.ValueString(), data.VpcEndpointID.ValueString()))
+	if response.IsError() {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to delete vpc endpoint restriction resource with a status code: %s", response.Status()), "")
+		return
+	}
+	resp.State.RemoveResource(ctx)
+}
+
+func (r vpcEndpointRestrictionResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identitySchema("project_id", "vpc_endpoint_id")
+}
+
+func (r vpcEndpointRestrictionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importState(ctx, req, resp, "vpc endpoint restriction", "project_id/vpc_endpoint_id")
+}
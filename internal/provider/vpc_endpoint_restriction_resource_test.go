@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestVpcEndpointRestrictionResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckVpcEndpoint(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testVpcEndpointRestrictionResource("label"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_project.test", "block_public_connections", "true"),
+					resource.TestCheckResourceAttr("neon_vpc_endpoint_restriction.test", "vpc_endpoint_id", os.Getenv("NEON_VPC_ENDPOINT_ID")),
+					resource.TestCheckResourceAttr("neon_vpc_endpoint_restriction.test", "label", "label"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "neon_vpc_endpoint_restriction.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					if rs, ok := s.RootModule().Resources["neon_vpc_endpoint_restriction.test"]; ok {
+						return fmt.Sprintf("%s/%s", rs.Primary.Attributes["project_id"], rs.Primary.Attributes["vpc_endpoint_id"]), nil
+					}
+					return "", fmt.Errorf("cannot find neon_vpc_endpoint_restriction.test")
+				},
+			},
+			// Update and Read testing
+			{
+				Config: testVpcEndpointRestrictionResource("label_updated"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_vpc_endpoint_restriction.test", "label", "label_updated"),
+				),
+			},
+		},
+	})
+}
+
+func testVpcEndpointRestrictionResource(label string) string {
+	return `
+resource "neon_vpc_endpoint" "test" {
+	org_id = "` + os.Getenv("NEON_ORG_ID") + `"
+	region_id = "aws-us-east-2"
+	vpc_endpoint_id = "` + os.Getenv("NEON_VPC_ENDPOINT_ID") + `"
+	label = "endpoint"
+}
+
+resource "neon_project" "test" {
+	name = "name_project"
+	org_id = neon_vpc_endpoint.test.org_id
+	region_id = "aws-us-east-2"
+	block_public_connections = true
+}
+
+resource "neon_vpc_endpoint_restriction" "test" {
+	project_id = neon_project.test.id
+	vpc_endpoint_id = neon_vpc_endpoint.test.vpc_endpoint_id
+	label = "` + label + `"
+}
+`
+}